@@ -25,6 +25,19 @@ const (
 	One = 10000000
 )
 
+// ParseMode controls how amounts with more than 7 digits of precision in the
+// fractional portion are handled when parsing.
+type ParseMode int
+
+const (
+	// ModeStrict rejects amounts with more than 7 digits of precision. This is
+	// the behaviour of Parse.
+	ModeStrict ParseMode = iota
+	// ModeRound rounds amounts with more than 7 digits of precision to the
+	// nearest stroop, rounding halfway cases to even.
+	ModeRound
+)
+
 var (
 	bigOne = big.NewRat(One, 1)
 	// validAmountSimple is a simple regular expression checking if a string looks like
@@ -33,8 +46,15 @@ var (
 	// to `big.Rat.SetString` triggering long calculations.
 	// Note: {1,20} because the biggest amount you can use in Stellar is:
 	// len("922337203685.4775807") = 20.
-	validAmountSimple          = regexp.MustCompile("^-?[.0-9]{1,20}$")
+	validAmountSimple = regexp.MustCompile("^-?[.0-9]{1,20}$")
+	// validRoundableAmount is used instead of validAmountSimple in ModeRound, where the
+	// fractional portion may carry more digits than are kept. The integer portion is still
+	// capped at 20 digits and the fractional portion at 40.
+	validRoundableAmount       = regexp.MustCompile(`^-?[0-9]{0,20}(\.[0-9]{0,40})?$`)
 	negativePositiveNumberOnly = regexp.MustCompile("^-?[0-9]+$")
+	// validGroupedAmount matches amounts whose integer portion is grouped in
+	// thousands with commas, e.g. "1,000,000.5".
+	validGroupedAmount = regexp.MustCompile(`^-?[0-9]{1,3}(,[0-9]{3})*(\.[0-9]*)?$`)
 )
 
 // MustParse is the panicking version of Parse.
@@ -57,11 +77,44 @@ func Parse(v string) (xdr.Int64, error) {
 	return xdr.Int64(i), nil
 }
 
+// ParseWithMode parses the provided as a stellar "amount" like Parse, using
+// mode to decide how excess precision in the fractional portion is handled.
+func ParseWithMode(v string, mode ParseMode) (xdr.Int64, error) {
+	i, err := parseInt64(v, mode)
+	if err != nil {
+		return xdr.Int64(0), err
+	}
+	return xdr.Int64(i), nil
+}
+
 // ParseInt64 parses the provided as a stellar "amount", i.e. a 64-bit signed
 // integer that represents a decimal number with 7 digits of significance in
 // the fractional portion of the number.
 func ParseInt64(v string) (int64, error) {
-	if !validAmountSimple.MatchString(v) {
+	return parseInt64(v, ModeStrict)
+}
+
+// ParseHuman parses an amount as entered by a person, which may contain
+// whitespace and commas grouping the integer portion in thousands, such as
+// "1,000.50". Malformed grouping, such as "1,00,0", is rejected. Otherwise it
+// behaves like ParseInt64.
+func ParseHuman(v string) (int64, error) {
+	v = strings.Join(strings.Fields(v), "")
+	if strings.Contains(v, ",") {
+		if !validGroupedAmount.MatchString(v) {
+			return 0, errors.Errorf("invalid amount grouping: %s", v)
+		}
+		v = strings.Replace(v, ",", "", -1)
+	}
+	return ParseInt64(v)
+}
+
+func parseInt64(v string, mode ParseMode) (int64, error) {
+	valid := validAmountSimple
+	if mode == ModeRound {
+		valid = validRoundableAmount
+	}
+	if !valid.MatchString(v) {
 		return 0, errors.Errorf("invalid amount format: %s", v)
 	}
 
@@ -72,7 +125,12 @@ func ParseInt64(v string) (int64, error) {
 
 	r.Mul(r, bigOne)
 	if !r.IsInt() {
-		return 0, errors.Errorf("more than 7 significant digits: %s", v)
+		switch mode {
+		case ModeRound:
+			r.SetInt(roundHalfEven(r))
+		default:
+			return 0, errors.Errorf("more than 7 significant digits: %s", v)
+		}
 	}
 
 	i, err := strconv.ParseInt(r.FloatString(0), 10, 64)
@@ -82,6 +140,66 @@ func ParseInt64(v string) (int64, error) {
 	return i, nil
 }
 
+// roundHalfEven returns r rounded to the nearest integer, with halfway cases
+// rounded to the nearest even integer.
+func roundHalfEven(r *big.Rat) *big.Int {
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+
+	// Compare twice the (absolute) remainder with the denominator to decide
+	// whether the fraction is below, at, or above one half.
+	twiceRem := new(big.Int).Abs(rem)
+	twiceRem.Lsh(twiceRem, 1)
+	cmp := twiceRem.Cmp(r.Denom())
+	if cmp > 0 || (cmp == 0 && q.Bit(0) == 1) {
+		if r.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+
+	return q
+}
+
+// Rat returns the amount represented by the provided number of stroops as an
+// exact rational number of whole units.
+func Rat(stroops int64) *big.Rat {
+	r := big.NewRat(stroops, 1)
+	return r.Quo(r, bigOne)
+}
+
+// FromRat converts an exact rational number of whole units to stroops. It
+// returns an error if the value is not a whole number of stroops or is outside
+// the bounds of int64.
+func FromRat(r *big.Rat) (int64, error) {
+	stroops := new(big.Rat).Mul(r, bigOne)
+	if !stroops.IsInt() {
+		return 0, errors.Errorf("not a whole number of stroops: %s", r.String())
+	}
+
+	n := stroops.Num()
+	if !n.IsInt64() {
+		return 0, errors.Errorf("amount outside bounds of int64: %s", r.String())
+	}
+	return n.Int64(), nil
+}
+
+// MulRatio returns stroops multiplied by num/den, truncated toward zero. The
+// intermediate product is computed exactly, so only a result outside the
+// bounds of int64 is an error.
+func MulRatio(stroops int64, num, den int64) (int64, error) {
+	if den == 0 {
+		return 0, errors.New("ratio denominator is zero")
+	}
+
+	r := new(big.Int).Mul(big.NewInt(stroops), big.NewInt(num))
+	r.Quo(r, big.NewInt(den))
+	if !r.IsInt64() {
+		return 0, errors.Errorf("amount outside bounds of int64: %d * %d / %d", stroops, num, den)
+	}
+	return r.Int64(), nil
+}
+
 // IntStringToAmount converts string integer value and converts it to stellar
 // "amount". In other words, it divides the given string integer value by 10^7
 // and returns the string representation of that number.
@@ -2,6 +2,7 @@ package amount_test
 
 import (
 	"fmt"
+	"math/big"
 	"strings"
 	"testing"
 
@@ -121,3 +122,167 @@ func TestIntStringToAmount(t *testing.T) {
 	}
 
 }
+
+func TestParseWithMode(t *testing.T) {
+	var testCases = []struct {
+		Input  string
+		Mode   amount.ParseMode
+		Output xdr.Int64
+		Valid  bool
+	}{
+		{"0.00000005", amount.ModeStrict, 0, false},
+		{"0.00000005", amount.ModeRound, 0, true},
+		{"0.00000015", amount.ModeRound, 2, true},
+		{"0.00000016", amount.ModeRound, 2, true},
+		{"0.00000014", amount.ModeRound, 1, true},
+		{"-0.00000015", amount.ModeRound, -2, true},
+		{"-0.00000025", amount.ModeRound, -2, true},
+		{"123.00000001", amount.ModeRound, 1230000000, true},
+		{"100.0000001", amount.ModeStrict, 1000000001, true},
+		{"100.0000001", amount.ModeRound, 1000000001, true},
+		{"12345.123456789012345", amount.ModeRound, 123451234568, true},
+		{"12345.123456789012345", amount.ModeStrict, 0, false},
+		{"922337203685.4775807", amount.ModeRound, 9223372036854775807, true},
+		{"922337203685.4775808", amount.ModeRound, 0, false},
+		{"922337203685.47758075", amount.ModeRound, 0, false},
+		{"1." + strings.Repeat("1", 41), amount.ModeRound, 0, false},
+		{strings.Repeat("1", 21), amount.ModeRound, 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s (mode = %d)", tc.Input, tc.Mode), func(t *testing.T) {
+			o, err := amount.ParseWithMode(tc.Input, tc.Mode)
+
+			if !tc.Valid && err == nil {
+				t.Errorf("expected err for input %s", tc.Input)
+				return
+			}
+			if tc.Valid && err != nil {
+				t.Errorf("couldn't parse %s: %v", tc.Input, err)
+				return
+			}
+
+			if o != tc.Output {
+				t.Errorf("%s parsed to %d, not %d", tc.Input, o, tc.Output)
+			}
+		})
+	}
+}
+
+func TestRat(t *testing.T) {
+	var testCases = []struct {
+		Stroops int64
+		Rat     *big.Rat
+	}{
+		{10000000, big.NewRat(1, 1)},
+		{5000000, big.NewRat(1, 2)},
+		{-1, big.NewRat(-1, 10000000)},
+		{0, big.NewRat(0, 1)},
+		{9223372036854775807, big.NewRat(9223372036854775807, 10000000)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%d stroops", tc.Stroops), func(t *testing.T) {
+			r := amount.Rat(tc.Stroops)
+			if r.Cmp(tc.Rat) != 0 {
+				t.Errorf("%d converted to %s, not %s", tc.Stroops, r, tc.Rat)
+			}
+
+			stroops, err := amount.FromRat(r)
+			if err != nil {
+				t.Errorf("couldn't convert %s: %v", r, err)
+				return
+			}
+			if stroops != tc.Stroops {
+				t.Errorf("%s converted to %d, not %d", r, stroops, tc.Stroops)
+			}
+		})
+	}
+
+	invalid := []*big.Rat{
+		big.NewRat(1, 3),
+		big.NewRat(1, 100000000),
+		new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), 64)),
+	}
+	for _, r := range invalid {
+		if _, err := amount.FromRat(r); err == nil {
+			t.Errorf("expected err for input %s", r)
+		}
+	}
+}
+
+func TestMulRatio(t *testing.T) {
+	var testCases = []struct {
+		Stroops int64
+		Num     int64
+		Den     int64
+		Output  int64
+		Valid   bool
+	}{
+		{1000, 1, 4, 250, true},
+		{1000, 3, 4, 750, true},
+		{10, 1, 3, 3, true},
+		{-10, 1, 3, -3, true},
+		{10, -2, 3, -6, true},
+		{9223372036854775807, 2, 2, 9223372036854775807, true},
+		{9223372036854775807, 3, 2, 0, false},
+		{1000, 1, 0, 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%d * %d / %d", tc.Stroops, tc.Num, tc.Den), func(t *testing.T) {
+			o, err := amount.MulRatio(tc.Stroops, tc.Num, tc.Den)
+
+			if !tc.Valid && err == nil {
+				t.Errorf("expected err for input %d * %d / %d", tc.Stroops, tc.Num, tc.Den)
+				return
+			}
+			if tc.Valid && err != nil {
+				t.Errorf("couldn't scale %d: %v", tc.Stroops, err)
+				return
+			}
+
+			if o != tc.Output {
+				t.Errorf("%d * %d / %d gave %d, not %d", tc.Stroops, tc.Num, tc.Den, o, tc.Output)
+			}
+		})
+	}
+}
+
+func TestParseHuman(t *testing.T) {
+	var testCases = []struct {
+		Input  string
+		Output int64
+		Valid  bool
+	}{
+		{"1,000.50", 10005000000, true},
+		{"1000.50", 10005000000, true},
+		{" 1,000,000 ", 10000000000000, true},
+		{"-1,234.5", -12345000000, true},
+		{"1 000.5", 10005000000, true},
+		{"1,00,0", 0, false},
+		{"1000,000", 0, false},
+		{",100", 0, false},
+		{"1,000.000,1", 0, false},
+		{"1,000.00000001", 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			o, err := amount.ParseHuman(tc.Input)
+
+			if !tc.Valid && err == nil {
+				t.Errorf("expected err for input %s", tc.Input)
+				return
+			}
+			if tc.Valid && err != nil {
+				t.Errorf("couldn't parse %s: %v", tc.Input, err)
+				return
+			}
+
+			if o != tc.Output {
+				t.Errorf("%s parsed to %d, not %d", tc.Input, o, tc.Output)
+			}
+		})
+	}
+}
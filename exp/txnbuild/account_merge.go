@@ -0,0 +1,53 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// AccountMerge represents the Stellar merge account operation. See
+// https://www.stellar.org/developers/guides/concepts/list-of-operations.html
+type AccountMerge struct {
+	Destination   string
+	SourceAccount string
+	destAccountID xdr.AccountId
+}
+
+// BuildXDR for AccountMerge returns a fully configured XDR Operation.
+func (am *AccountMerge) BuildXDR() (xdr.Operation, error) {
+	err := am.destAccountID.SetAddress(am.Destination)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, withValue("Failed to set destination address", am.Destination))
+	}
+
+	opType := xdr.OperationTypeAccountMerge
+	body, err := xdr.NewOperationBody(opType, am.destAccountID)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to build XDR OperationBody")
+	}
+
+	op := xdr.Operation{Body: body}
+	err = setOpSourceAccount(&op, am.SourceAccount)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to set source account")
+	}
+
+	return op, nil
+}
+
+// GetSourceAccount returns the source account of the AccountMerge operation, if one is set.
+func (am *AccountMerge) GetSourceAccount() string {
+	return am.SourceAccount
+}
+
+// FromXDR for AccountMerge initialises the operation from an XDR Operation.
+func (am *AccountMerge) FromXDR(xdrOp xdr.Operation) error {
+	destination, ok := xdrOp.Body.GetDestination()
+	if !ok {
+		return errors.New("Operation is not an account merge")
+	}
+
+	am.Destination = destination.Address()
+	am.SourceAccount = opSourceAccountFromXDR(xdrOp)
+	return nil
+}
@@ -0,0 +1,134 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// AllowTrust represents the Stellar allow trust operation, which authorises or deauthorises
+// a trustline to an asset. Only the code of Type is used; the asset's issuer is the source
+// account of the operation. See
+// https://www.stellar.org/developers/guides/concepts/list-of-operations.html
+type AllowTrust struct {
+	Trustor       string
+	Type          Asset
+	Authorize     bool
+	SourceAccount string
+	xdrOp         xdr.AllowTrustOp
+}
+
+// NewAuthorizeTrustOp returns an AllowTrust operation that authorises, or deauthorises, the
+// trustor's trustline to the asset. The operation's source account is set to the asset's
+// issuer, so the Transaction must be signed by the issuer.
+func NewAuthorizeTrustOp(trustor string, asset Asset, authorize bool) (*AllowTrust, error) {
+	if asset.IsNative() {
+		return nil, errors.New("Trustlines can't be authorised for the native asset")
+	}
+
+	_, err := strkey.Decode(strkey.VersionByteAccountID, trustor)
+	if err != nil {
+		return nil, errors.Wrap(err, withValue("Invalid trustor address", trustor))
+	}
+
+	_, err = asset.ToXDR()
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid asset")
+	}
+
+	return &AllowTrust{
+		Trustor:       trustor,
+		Type:          asset,
+		Authorize:     authorize,
+		SourceAccount: asset.Issuer,
+	}, nil
+}
+
+// BuildXDR for AllowTrust returns a fully configured XDR Operation.
+func (at *AllowTrust) BuildXDR() (xdr.Operation, error) {
+	err := at.xdrOp.Trustor.SetAddress(at.Trustor)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, withValue("Failed to set trustor address", at.Trustor))
+	}
+
+	at.xdrOp.Asset, err = allowTrustAssetToXDR(at.Type)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to set asset type")
+	}
+	at.xdrOp.Authorize = at.Authorize
+
+	opType := xdr.OperationTypeAllowTrust
+	body, err := xdr.NewOperationBody(opType, at.xdrOp)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to build XDR OperationBody")
+	}
+
+	op := xdr.Operation{Body: body}
+	err = setOpSourceAccount(&op, at.SourceAccount)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to set source account")
+	}
+
+	return op, nil
+}
+
+// allowTrustAssetToXDR returns the XDR representation of an asset's code, as used by the
+// allow trust operation.
+func allowTrustAssetToXDR(asset Asset) (xdr.AllowTrustOpAsset, error) {
+	if asset.IsNative() {
+		return xdr.AllowTrustOpAsset{}, errors.New("Trustlines can't be authorised for the native asset")
+	}
+
+	code := normalizeAssetCode([]byte(asset.Code))
+	err := validateAssetCode(code)
+	if err != nil {
+		return xdr.AllowTrustOpAsset{}, errors.Wrapf(err, "Invalid asset code %q", asset.Code)
+	}
+
+	length := len(code)
+	switch {
+	case length >= 1 && length <= 4:
+		var codeArray [4]byte
+		copy(codeArray[:], code)
+		return xdr.NewAllowTrustOpAsset(xdr.AssetTypeAssetTypeCreditAlphanum4, codeArray)
+	case length >= 5 && length <= 12:
+		var codeArray [12]byte
+		copy(codeArray[:], code)
+		return xdr.NewAllowTrustOpAsset(xdr.AssetTypeAssetTypeCreditAlphanum12, codeArray)
+	default:
+		return xdr.AllowTrustOpAsset{}, errors.New("Asset code length is invalid")
+	}
+}
+
+// GetSourceAccount returns the source account of the AllowTrust operation, if one is set.
+func (at *AllowTrust) GetSourceAccount() string {
+	return at.SourceAccount
+}
+
+// FromXDR for AllowTrust initialises the operation from an XDR Operation. As the XDR only
+// carries the asset code, the issuer of Type is taken from the operation's source account,
+// and is empty if the operation has none.
+func (at *AllowTrust) FromXDR(xdrOp xdr.Operation) error {
+	result, ok := xdrOp.Body.GetAllowTrustOp()
+	if !ok {
+		return errors.New("Operation is not an allow trust")
+	}
+
+	var code string
+	switch result.Asset.Type {
+	case xdr.AssetTypeAssetTypeCreditAlphanum4:
+		codeArray := result.Asset.MustAssetCode4()
+		code = normalizeAssetCode(codeArray[:])
+	case xdr.AssetTypeAssetTypeCreditAlphanum12:
+		codeArray := result.Asset.MustAssetCode12()
+		code = normalizeAssetCode(codeArray[:])
+	default:
+		return errors.Errorf("Unknown asset type %d", result.Asset.Type)
+	}
+
+	at.SourceAccount = opSourceAccountFromXDR(xdrOp)
+	at.Trustor = result.Trustor.Address()
+	at.Type = Asset{Code: code, Issuer: at.SourceAccount}
+	at.Authorize = result.Authorize
+	return nil
+}
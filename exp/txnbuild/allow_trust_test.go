@@ -0,0 +1,66 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuthorizeTrustOp(t *testing.T) {
+	issuer := newKeypair0()
+	trustor := newKeypair1()
+	usd := Asset{Code: "USD", Issuer: issuer.Address()}
+
+	authorize, err := NewAuthorizeTrustOp(trustor.Address(), usd, true)
+	assert.Nil(t, err)
+	assert.Equal(t, issuer.Address(), authorize.SourceAccount)
+	xdrOp, err := authorize.BuildXDR()
+	assert.Nil(t, err)
+	op := xdrOp.Body.MustAllowTrustOp()
+	assert.True(t, op.Authorize)
+	assert.Equal(t, trustor.Address(), op.Trustor.Address())
+	assert.Equal(t, [4]byte{'U', 'S', 'D', 0}, op.Asset.MustAssetCode4())
+	assert.Equal(t, issuer.Address(), xdrOp.SourceAccount.Address())
+
+	deauthorize, err := NewAuthorizeTrustOp(trustor.Address(), usd, false)
+	assert.Nil(t, err)
+	xdrOp, err = deauthorize.BuildXDR()
+	assert.Nil(t, err)
+	assert.False(t, xdrOp.Body.MustAllowTrustOp().Authorize)
+
+	_, err = NewAuthorizeTrustOp(trustor.Address(), NewNativeAsset(), true)
+	assert.Error(t, err)
+	_, err = NewAuthorizeTrustOp("GBADADDRESS", usd, true)
+	assert.Error(t, err)
+}
+
+func TestAllowTrustRoundTrip(t *testing.T) {
+	issuer := newKeypair0()
+	trustor := newKeypair1()
+	allowTrust, err := NewAuthorizeTrustOp(trustor.Address(), Asset{Code: "LONGCODE", Issuer: issuer.Address()}, true)
+	assert.Nil(t, err)
+
+	tx := Transaction{
+		SourceAccount: Account{ID: issuer.Address(), SequenceNumber: 1},
+		Operations:    []Operation{allowTrust},
+		Network:       network.TestNetworkPassphrase,
+	}
+	err = tx.Build()
+	assert.Nil(t, err)
+	err = tx.Sign(issuer)
+	assert.Nil(t, err)
+	txeB64, err := tx.Base64()
+	assert.Nil(t, err)
+
+	decoded, err := TransactionFromXDR(txeB64, network.TestNetworkPassphrase)
+	assert.Nil(t, err)
+	opTypes, err := decoded.OperationTypes()
+	assert.Nil(t, err)
+	assert.Equal(t, []xdr.OperationType{xdr.OperationTypeAllowTrust}, opTypes)
+	decodedOp := decoded.Operations[0].(*AllowTrust)
+	assert.Equal(t, trustor.Address(), decodedOp.Trustor)
+	assert.Equal(t, Asset{Code: "LONGCODE", Issuer: issuer.Address()}, decodedOp.Type)
+	assert.True(t, decodedOp.Authorize)
+}
@@ -0,0 +1,143 @@
+package txnbuild
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// Asset represents a Stellar asset. The native asset (lumens) is represented by an Asset
+// with an empty Code and Issuer.
+type Asset struct {
+	Code   string
+	Issuer string
+}
+
+// NewNativeAsset returns an Asset representing lumens.
+func NewNativeAsset() Asset {
+	return Asset{}
+}
+
+// IsNative returns true if the Asset represents lumens.
+func (a Asset) IsNative() bool {
+	return a.Code == "" && a.Issuer == ""
+}
+
+// String returns the canonical string form of the Asset: "native" for lumens, and
+// "CODE:ISSUER" for other assets.
+func (a Asset) String() string {
+	if a.IsNative() {
+		return "native"
+	}
+	return a.Code + ":" + a.Issuer
+}
+
+// ParseAsset returns the Asset represented by its canonical string form, as produced by
+// Asset.String.
+func ParseAsset(s string) (Asset, error) {
+	if s == "native" {
+		return NewNativeAsset(), nil
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return Asset{}, errors.Errorf("Invalid asset %q: expected \"native\" or \"CODE:ISSUER\"", s)
+	}
+
+	asset := Asset{Code: parts[0], Issuer: parts[1]}
+	_, err := asset.ToXDR()
+	if err != nil {
+		return Asset{}, errors.Wrapf(err, "Invalid asset %q", s)
+	}
+
+	return asset, nil
+}
+
+// ToXDR for Asset returns an XDR object representation of the Asset.
+func (a Asset) ToXDR() (xdr.Asset, error) {
+	if a.IsNative() {
+		return xdr.NewAsset(xdr.AssetTypeAssetTypeNative, nil)
+	}
+
+	if a.Issuer == "" {
+		return xdr.Asset{}, errors.Errorf("Asset %q has no issuer", a.Code)
+	}
+	_, err := strkey.Decode(strkey.VersionByteAccountID, a.Issuer)
+	if err != nil {
+		return xdr.Asset{}, errors.Wrapf(err, "Invalid asset issuer %q", a.Issuer)
+	}
+
+	var issuer xdr.AccountId
+	err = issuer.SetAddress(a.Issuer)
+	if err != nil {
+		return xdr.Asset{}, errors.Wrap(err, "Failed to set asset issuer address")
+	}
+
+	// Codes decoded from XDR may carry trailing null padding, which is not part of the code
+	code := normalizeAssetCode([]byte(a.Code))
+	err = validateAssetCode(code)
+	if err != nil {
+		return xdr.Asset{}, errors.Wrapf(err, "Invalid asset code %q", a.Code)
+	}
+
+	length := len(code)
+	switch {
+	case length >= 1 && length <= 4:
+		var codeArray [4]byte
+		copy(codeArray[:], code)
+		asset := xdr.AssetAlphaNum4{AssetCode: codeArray, Issuer: issuer}
+		return xdr.NewAsset(xdr.AssetTypeAssetTypeCreditAlphanum4, asset)
+	case length >= 5 && length <= 12:
+		var codeArray [12]byte
+		copy(codeArray[:], code)
+		asset := xdr.AssetAlphaNum12{AssetCode: codeArray, Issuer: issuer}
+		return xdr.NewAsset(xdr.AssetTypeAssetTypeCreditAlphanum12, asset)
+	default:
+		return xdr.Asset{}, errors.New("Asset code length is invalid")
+	}
+}
+
+// validateAssetCode returns an error if the code contains anything other than ASCII letters
+// and digits.
+func validateAssetCode(code string) error {
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+		isAlphanumeric := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !isAlphanumeric {
+			return errors.Errorf("invalid character %q at position %d", c, i)
+		}
+	}
+
+	return nil
+}
+
+// assetFromXDR returns the Asset represented by an XDR asset.
+func assetFromXDR(xdrAsset xdr.Asset) (Asset, error) {
+	switch xdrAsset.Type {
+	case xdr.AssetTypeAssetTypeNative:
+		return NewNativeAsset(), nil
+	case xdr.AssetTypeAssetTypeCreditAlphanum4:
+		an, ok := xdrAsset.GetAlphaNum4()
+		if !ok {
+			return Asset{}, errors.New("Failed to extract alphanum4 asset")
+		}
+		return Asset{Code: normalizeAssetCode(an.AssetCode[:]), Issuer: an.Issuer.Address()}, nil
+	case xdr.AssetTypeAssetTypeCreditAlphanum12:
+		an, ok := xdrAsset.GetAlphaNum12()
+		if !ok {
+			return Asset{}, errors.New("Failed to extract alphanum12 asset")
+		}
+		return Asset{Code: normalizeAssetCode(an.AssetCode[:]), Issuer: an.Issuer.Address()}, nil
+	default:
+		return Asset{}, errors.Errorf("Unknown asset type %d", xdrAsset.Type)
+	}
+}
+
+// normalizeAssetCode returns the human readable form of an XDR asset code, without the
+// trailing null bytes that pad it to 4 or 12 bytes.
+func normalizeAssetCode(code []byte) string {
+	return string(bytes.TrimRight(code, "\x00"))
+}
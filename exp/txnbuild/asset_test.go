@@ -0,0 +1,91 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetToXDRCodeCharacters(t *testing.T) {
+	issuer := "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"
+
+	xdrAsset, err := Asset{Code: "USD", Issuer: issuer}.ToXDR()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.AssetTypeAssetTypeCreditAlphanum4, xdrAsset.Type)
+
+	padded, err := Asset{Code: "USD\x00", Issuer: issuer}.ToXDR()
+	assert.Nil(t, err)
+	assert.True(t, xdrAsset.Equals(padded), "Null padding should be ignored")
+
+	_, err = Asset{Code: "US D", Issuer: issuer}.ToXDR()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `"US D"`)
+	}
+
+	_, err = Asset{Code: "€UR", Issuer: issuer}.ToXDR()
+	assert.Error(t, err)
+}
+
+func TestAssetString(t *testing.T) {
+	issuer := "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"
+	usd := Asset{Code: "USD", Issuer: issuer}
+
+	assert.Equal(t, "native", NewNativeAsset().String())
+	assert.Equal(t, "USD:"+issuer, usd.String())
+
+	asset, err := ParseAsset("native")
+	assert.Nil(t, err)
+	assert.True(t, asset.IsNative())
+
+	asset, err = ParseAsset(usd.String())
+	assert.Nil(t, err)
+	assert.Equal(t, usd, asset)
+
+	for _, s := range []string{"", "USD", "USD:", ":" + issuer, "USD:" + issuer + ":x", "USD:GBADISSUER", "U$D:" + issuer} {
+		_, err = ParseAsset(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestAssetFromXDRPaddedCodes(t *testing.T) {
+	issuer := "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"
+	var issuerID xdr.AccountId
+	err := issuerID.SetAddress(issuer)
+	assert.Nil(t, err)
+
+	var code4 [4]byte
+	copy(code4[:], "USD")
+	xdrAsset, err := xdr.NewAsset(xdr.AssetTypeAssetTypeCreditAlphanum4, xdr.AssetAlphaNum4{AssetCode: code4, Issuer: issuerID})
+	assert.Nil(t, err)
+	asset, err := assetFromXDR(xdrAsset)
+	assert.Nil(t, err)
+	assert.Equal(t, Asset{Code: "USD", Issuer: issuer}, asset)
+
+	var code12 [12]byte
+	copy(code12[:], "LONGCODE")
+	xdrAsset, err = xdr.NewAsset(xdr.AssetTypeAssetTypeCreditAlphanum12, xdr.AssetAlphaNum12{AssetCode: code12, Issuer: issuerID})
+	assert.Nil(t, err)
+	asset, err = assetFromXDR(xdrAsset)
+	assert.Nil(t, err)
+	assert.Equal(t, Asset{Code: "LONGCODE", Issuer: issuer}, asset)
+
+	assert.Equal(t, "USD", normalizeAssetCode([]byte("USD\x00")))
+	assert.Equal(t, "ABCD", normalizeAssetCode([]byte("ABCD")))
+}
+
+func TestAssetToXDRIssuer(t *testing.T) {
+	_, err := Asset{Code: "USD", Issuer: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"}.ToXDR()
+	assert.Nil(t, err)
+
+	_, err = Asset{Code: "USD"}.ToXDR()
+	assert.EqualError(t, err, `Asset "USD" has no issuer`)
+
+	_, err = Asset{Code: "USD", Issuer: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7X"}.ToXDR()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `Invalid asset issuer "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7X"`)
+	}
+
+	_, err = NewNativeAsset().ToXDR()
+	assert.Nil(t, err, "Native assets have no issuer to check")
+}
@@ -8,8 +8,9 @@ import (
 // BumpSequence represents the Stellar bump sequence operation. See
 // https://www.stellar.org/developers/guides/concepts/list-of-operations.html
 type BumpSequence struct {
-	BumpTo int64
-	xdrOp  xdr.BumpSequenceOp
+	BumpTo        int64
+	SourceAccount string
+	xdrOp         xdr.BumpSequenceOp
 }
 
 // BuildXDR for BumpSequence returns a fully configured XDR Operation.
@@ -23,5 +24,28 @@ func (bs *BumpSequence) BuildXDR() (xdr.Operation, error) {
 		return xdr.Operation{}, errors.Wrap(err, "Failed to build XDR OperationBody")
 	}
 
-	return xdr.Operation{Body: body}, nil
+	op := xdr.Operation{Body: body}
+	err = setOpSourceAccount(&op, bs.SourceAccount)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to set source account")
+	}
+
+	return op, nil
+}
+
+// GetSourceAccount returns the source account of the BumpSequence operation, if one is set.
+func (bs *BumpSequence) GetSourceAccount() string {
+	return bs.SourceAccount
+}
+
+// FromXDR for BumpSequence initialises the operation from an XDR Operation.
+func (bs *BumpSequence) FromXDR(xdrOp xdr.Operation) error {
+	result, ok := xdrOp.Body.GetBumpSequenceOp()
+	if !ok {
+		return errors.New("Operation is not a bump sequence")
+	}
+
+	bs.BumpTo = int64(result.BumpTo)
+	bs.SourceAccount = opSourceAccountFromXDR(xdrOp)
+	return nil
 }
@@ -0,0 +1,113 @@
+package txnbuild
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/support/errors"
+)
+
+// DefaultChallengeMaxWindow is the default maximum validity window of a SEP-10 challenge
+// transaction.
+const DefaultChallengeMaxWindow = 15 * time.Minute
+
+// challengeNonceLength is the number of random bytes in a SEP-10 challenge nonce.
+const challengeNonceLength = 48
+
+// ChallengeNonce returns a new random nonce for the manage data operation of a SEP-10
+// challenge transaction. The nonce is 48 random bytes, base 64 encoded to 64 bytes so that
+// it fits in a data entry value. See
+// https://github.com/stellar/stellar-protocol/blob/master/ecosystem/sep-0010.md
+func ChallengeNonce() ([]byte, error) {
+	randomBytes := make([]byte, challengeNonceLength)
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to generate random nonce")
+	}
+
+	nonce := make([]byte, base64.StdEncoding.EncodedLen(challengeNonceLength))
+	base64.StdEncoding.Encode(nonce, randomBytes)
+	return nonce, nil
+}
+
+// ChallengeDataName returns the name of the manage data operation of a SEP-10 challenge
+// transaction issued by the given home domain.
+func ChallengeDataName(homeDomain string) string {
+	return homeDomain + " auth"
+}
+
+// NewChallengeTx returns a SEP-10 challenge transaction for the client account, signed by the
+// server. The transaction has sequence number 0, so it can never be submitted, and a single
+// manage data operation, sourced from the client account, carrying a random nonce. The
+// timebounds must be bounded and no longer than maxWindow; a maxWindow of 0 uses
+// DefaultChallengeMaxWindow.
+func NewChallengeTx(server *keypair.Full, clientAccountID, homeDomain string, network Network, timebounds Timebounds, maxWindow time.Duration) (*Transaction, error) {
+	err := ValidateChallengeTimebounds(timebounds, maxWindow)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid challenge time bounds")
+	}
+
+	nonce, err := ChallengeNonce()
+	if err != nil {
+		return nil, err
+	}
+
+	tx := Transaction{
+		SourceAccount: Account{ID: server.Address(), SequenceNumber: -1},
+		Operations: []Operation{&ManageData{
+			Name:          ChallengeDataName(homeDomain),
+			Value:         nonce,
+			SourceAccount: clientAccountID,
+		}},
+		Network:    network,
+		Timebounds: timebounds,
+	}
+
+	err = tx.Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to build challenge transaction")
+	}
+
+	err = tx.Sign(server)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to sign challenge transaction")
+	}
+
+	return &tx, nil
+}
+
+// ValidateChallengeTimebounds returns an error if the timebounds of a SEP-10 challenge
+// transaction have a validity window that is unbounded, empty or longer than maxWindow. A
+// maxWindow of 0 uses DefaultChallengeMaxWindow.
+func ValidateChallengeTimebounds(timebounds Timebounds, maxWindow time.Duration) error {
+	if maxWindow == 0 {
+		maxWindow = DefaultChallengeMaxWindow
+	}
+
+	err := timebounds.Validate()
+	if err != nil {
+		return err
+	}
+	if timebounds.MaxTime == 0 {
+		return errors.New("challenge must have a max time")
+	}
+
+	// Compare in whole seconds, as a window of more than about 292 years overflows a Duration
+	seconds := uint64(timebounds.MaxTime - timebounds.MinTime)
+	if seconds == 0 {
+		return errors.New("challenge validity window can't be zero")
+	}
+	if seconds > uint64(maxWindow/time.Second) {
+		window := fmt.Sprintf("%ds", seconds)
+		if seconds <= uint64(math.MaxInt64/time.Second) {
+			window = (time.Duration(seconds) * time.Second).String()
+		}
+		return errors.Errorf("challenge validity window %s is longer than %s", window, maxWindow)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package txnbuild
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChallengeNonce(t *testing.T) {
+	nonce, err := ChallengeNonce()
+	assert.Nil(t, err)
+	assert.Equal(t, 64, len(nonce))
+
+	randomBytes, err := base64.StdEncoding.DecodeString(string(nonce))
+	assert.Nil(t, err)
+	assert.Equal(t, 48, len(randomBytes))
+
+	other, err := ChallengeNonce()
+	assert.Nil(t, err)
+	assert.NotEqual(t, nonce, other)
+
+	_, err = (&ManageData{Name: ChallengeDataName("example.com"), Value: nonce}).BuildXDR()
+	assert.Nil(t, err, "Nonce should fit in a data entry")
+}
+
+func TestChallengeDataName(t *testing.T) {
+	assert.Equal(t, "example.com auth", ChallengeDataName("example.com"))
+}
+
+func TestNewChallengeTx(t *testing.T) {
+	server := newKeypair0()
+	client := newKeypair1()
+	now := time.Now().UTC().Unix()
+
+	tx, err := NewChallengeTx(server, client.Address(), "example.com", network.TestNetworkPassphrase, NewTimebounds(now, now+300), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.SequenceNumber(0), tx.xdrTransaction.SeqNum)
+	assert.Nil(t, tx.VerifySignatures(server))
+	op := tx.xdrTransaction.Operations[0]
+	assert.Equal(t, client.Address(), op.SourceAccount.Address())
+	assert.Equal(t, xdr.String64("example.com auth"), op.Body.MustManageDataOp().DataName)
+
+	_, err = NewChallengeTx(server, client.Address(), "example.com", network.TestNetworkPassphrase, NewTimebounds(now, now), 0)
+	assert.Error(t, err)
+
+	_, err = NewChallengeTx(server, client.Address(), "example.com", network.TestNetworkPassphrase, NewTimebounds(now, now+3600), 0)
+	assert.Error(t, err)
+}
+
+func TestValidateChallengeTimebounds(t *testing.T) {
+	assert.Nil(t, ValidateChallengeTimebounds(NewTimebounds(1000, 1300), 0))
+	assert.Nil(t, ValidateChallengeTimebounds(NewTimebounds(1000, 1000+15*60), 0))
+	assert.Nil(t, ValidateChallengeTimebounds(NewTimebounds(1000, 4600), time.Hour), "Max window should be configurable")
+
+	assert.EqualError(t, ValidateChallengeTimebounds(NewTimebounds(1000, 1000), 0), "challenge validity window can't be zero")
+	assert.EqualError(t, ValidateChallengeTimebounds(NewTimebounds(1000, 1000+15*60+1), 0), "challenge validity window 15m1s is longer than 15m0s")
+	assert.EqualError(t, ValidateChallengeTimebounds(NewTimebounds(1000, 1300), 4*time.Minute), "challenge validity window 5m0s is longer than 4m0s")
+	assert.EqualError(t, ValidateChallengeTimebounds(NewTimebounds(1000, 0), 0), "challenge must have a max time")
+
+	// A window whose length in nanoseconds overflows int64 must not wrap around to a short one
+	assert.EqualError(t, ValidateChallengeTimebounds(NewTimebounds(0, 18446744074), 0), "challenge validity window 18446744074s is longer than 15m0s")
+}
@@ -0,0 +1,94 @@
+package txnbuild
+
+import (
+	"math"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// MaxTrustlineLimit represents the maximum value that can be set as a trustline limit.
+var MaxTrustlineLimit = amount.StringFromInt64(math.MaxInt64)
+
+// ChangeTrust represents the Stellar change trust operation. If Limit is empty, the
+// maximum trustline limit is used. A Limit of "0" removes the trustline. See
+// https://www.stellar.org/developers/guides/concepts/list-of-operations.html
+type ChangeTrust struct {
+	Line          Asset
+	Limit         string
+	SourceAccount string
+	xdrOp         xdr.ChangeTrustOp
+}
+
+// NewRemoveTrustlineOp returns a ChangeTrust operation that removes the trustline for the
+// given asset, by setting its limit to zero.
+func NewRemoveTrustlineOp(asset Asset) (*ChangeTrust, error) {
+	if asset.IsNative() {
+		return nil, errors.New("Trustlines can't be removed for the native asset")
+	}
+
+	return &ChangeTrust{Line: asset, Limit: "0"}, nil
+}
+
+// BuildXDR for ChangeTrust returns a fully configured XDR Operation.
+func (ct *ChangeTrust) BuildXDR() (xdr.Operation, error) {
+	if ct.Line.IsNative() {
+		return xdr.Operation{}, errors.New("Trustlines can't be created for the native asset")
+	}
+
+	var err error
+	ct.xdrOp.Line, err = ct.Line.ToXDR()
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to set trustline asset")
+	}
+
+	limit := ct.Limit
+	if limit == "" {
+		limit = MaxTrustlineLimit
+	}
+	ct.xdrOp.Limit, err = amount.Parse(limit)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to parse limit")
+	}
+	if ct.xdrOp.Limit < 0 {
+		return xdr.Operation{}, errors.Errorf("Limit can't be negative: %s", limit)
+	}
+
+	opType := xdr.OperationTypeChangeTrust
+	body, err := xdr.NewOperationBody(opType, ct.xdrOp)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to build XDR OperationBody")
+	}
+
+	op := xdr.Operation{Body: body}
+	err = setOpSourceAccount(&op, ct.SourceAccount)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to set source account")
+	}
+
+	return op, nil
+}
+
+// GetSourceAccount returns the source account of the ChangeTrust operation, if one is set.
+func (ct *ChangeTrust) GetSourceAccount() string {
+	return ct.SourceAccount
+}
+
+// FromXDR for ChangeTrust initialises the operation from an XDR Operation.
+func (ct *ChangeTrust) FromXDR(xdrOp xdr.Operation) error {
+	result, ok := xdrOp.Body.GetChangeTrustOp()
+	if !ok {
+		return errors.New("Operation is not a change trust")
+	}
+
+	line, err := assetFromXDR(result.Line)
+	if err != nil {
+		return errors.Wrap(err, "Failed to decode trustline asset")
+	}
+
+	ct.Line = line
+	ct.Limit = amount.String(result.Limit)
+	ct.SourceAccount = opSourceAccountFromXDR(xdrOp)
+	return nil
+}
@@ -0,0 +1,44 @@
+package txnbuild
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRemoveTrustlineOp(t *testing.T) {
+	asset := Asset{Code: "USD", Issuer: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"}
+
+	removeTrust, err := NewRemoveTrustlineOp(asset)
+	assert.Nil(t, err)
+	assert.Equal(t, "0", removeTrust.Limit)
+
+	xdrOp, err := removeTrust.BuildXDR()
+	assert.Nil(t, err)
+	op := xdrOp.Body.MustChangeTrustOp()
+	assert.Equal(t, xdr.Int64(0), op.Limit)
+	assert.Equal(t, xdr.AssetTypeAssetTypeCreditAlphanum4, op.Line.Type)
+
+	_, err = NewRemoveTrustlineOp(NewNativeAsset())
+	assert.Error(t, err)
+}
+
+func TestChangeTrustLimit(t *testing.T) {
+	asset := Asset{Code: "USD", Issuer: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"}
+
+	negative := ChangeTrust{Line: asset, Limit: "-1"}
+	_, err := negative.BuildXDR()
+	assert.Error(t, err)
+
+	positive := ChangeTrust{Line: asset, Limit: "1000"}
+	xdrOp, err := positive.BuildXDR()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.Int64(10000000000), xdrOp.Body.MustChangeTrustOp().Limit)
+
+	unlimited := ChangeTrust{Line: asset}
+	xdrOp, err = unlimited.BuildXDR()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.Int64(math.MaxInt64), xdrOp.Body.MustChangeTrustOp().Limit)
+}
@@ -13,6 +13,7 @@ type CreateAccount struct {
 	Destination   string
 	Amount        string
 	Asset         string // TODO: Not used yet
+	SourceAccount string
 	xdrOp         xdr.CreateAccountOp
 }
 
@@ -20,7 +21,7 @@ type CreateAccount struct {
 func (ca *CreateAccount) BuildXDR() (xdr.Operation, error) {
 	err := ca.destAccountID.SetAddress(ca.Destination)
 	if err != nil {
-		return xdr.Operation{}, errors.Wrap(err, "Failed to set destination address")
+		return xdr.Operation{}, errors.Wrap(err, withValue("Failed to set destination address", ca.Destination))
 	}
 	ca.xdrOp.Destination = ca.destAccountID
 
@@ -35,5 +36,60 @@ func (ca *CreateAccount) BuildXDR() (xdr.Operation, error) {
 		return xdr.Operation{}, errors.Wrap(err, "Failed to build XDR OperationBody")
 	}
 
-	return xdr.Operation{Body: body}, nil
+	op := xdr.Operation{Body: body}
+	err = setOpSourceAccount(&op, ca.SourceAccount)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to set source account")
+	}
+
+	return op, nil
+}
+
+// GetSourceAccount returns the source account of the CreateAccount operation, if one is set.
+func (ca *CreateAccount) GetSourceAccount() string {
+	return ca.SourceAccount
+}
+
+// FromXDR for CreateAccount initialises the operation from an XDR Operation.
+func (ca *CreateAccount) FromXDR(xdrOp xdr.Operation) error {
+	result, ok := xdrOp.Body.GetCreateAccountOp()
+	if !ok {
+		return errors.New("Operation is not a create account")
+	}
+
+	ca.Destination = result.Destination.Address()
+	ca.Amount = amount.String(result.StartingBalance)
+	ca.SourceAccount = opSourceAccountFromXDR(xdrOp)
+	return nil
+}
+
+// NewAccountSetupTx returns a built Transaction that creates newAccount with the given
+// starting balance, adds a trustline to each of the given assets and, if options is not nil,
+// applies the SetOptions operation. The trustline and SetOptions operations use newAccount as
+// their source account, so the Transaction must be signed by both source and newAccount. A
+// baseFee of 0 uses the default base fee.
+func NewAccountSetupTx(source Account, network Network, newAccount, startingBalance string, trustlines []Asset, options *SetOptions, baseFee uint32) (*Transaction, error) {
+	ops := []Operation{&CreateAccount{Destination: newAccount, Amount: startingBalance}}
+	for _, asset := range trustlines {
+		ops = append(ops, &ChangeTrust{Line: asset, SourceAccount: newAccount})
+	}
+	if options != nil {
+		setOptions := *options
+		setOptions.SourceAccount = newAccount
+		ops = append(ops, &setOptions)
+	}
+
+	tx := Transaction{
+		SourceAccount: source,
+		Operations:    ops,
+		BaseFee:       uint64(baseFee),
+		Network:       network,
+	}
+
+	err := tx.Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to build account setup transaction")
+	}
+
+	return &tx, nil
 }
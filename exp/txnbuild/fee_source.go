@@ -0,0 +1,37 @@
+package txnbuild
+
+import (
+	"math"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// FeeSource provides the base fee (in stroops per operation) to use when building a
+// Transaction. It allows fee strategies, such as querying Horizon fee stats, to be
+// plugged into the Transaction.
+type FeeSource interface {
+	BaseFee() (uint32, error)
+}
+
+// StaticFeeSource is a FeeSource that always returns the same base fee.
+type StaticFeeSource uint32
+
+// BaseFee for StaticFeeSource returns the configured fee.
+func (fs StaticFeeSource) BaseFee() (uint32, error) {
+	return uint32(fs), nil
+}
+
+// RecommendedFee returns the minimum total fee for a Transaction with the given number of
+// operations, paying perOpFee for each. It can be used before a Transaction is built.
+func RecommendedFee(opCount int, perOpFee uint32) (uint32, error) {
+	if opCount < 0 {
+		return 0, errors.Errorf("operation count can't be negative: %d", opCount)
+	}
+
+	fee := uint64(opCount) * uint64(perOpFee)
+	if uint64(opCount) > math.MaxUint32 || fee > math.MaxUint32 {
+		return 0, errors.Errorf("fee overflows uint32: base fee %d for %d operations", perOpFee, opCount)
+	}
+
+	return uint32(fee), nil
+}
@@ -0,0 +1,135 @@
+package txnbuild
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+type erroringFeeSource struct{}
+
+func (fs erroringFeeSource) BaseFee() (uint32, error) {
+	return 0, errors.New("fee stats unavailable")
+}
+
+func TestStaticFeeSource(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 1},
+		Operations:    []Operation{&Inflation{}, &Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+		FeeSource:     StaticFeeSource(250),
+	}
+
+	err := tx.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.Uint32(500), tx.xdrTransaction.Fee, "Fee should be base fee times operation count")
+	assert.Equal(t, uint64(0), tx.BaseFee, "The fee from the fee source shouldn't be stored as the base fee")
+}
+
+func TestFeeSourceQueriedOnEveryBuild(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 1},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+
+	err := tx.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.Uint32(100), tx.xdrTransaction.Fee)
+
+	tx.FeeSource = StaticFeeSource(500)
+	err = tx.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.Uint32(500), tx.xdrTransaction.Fee)
+
+	tx.Reset()
+	tx.FeeSource = StaticFeeSource(250)
+	err = tx.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.Uint32(250), tx.xdrTransaction.Fee)
+}
+
+func TestFeeSourceIgnoredWithExplicitBaseFee(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 1},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+		BaseFee:       300,
+		FeeSource:     erroringFeeSource{},
+	}
+
+	err := tx.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.Uint32(300), tx.xdrTransaction.Fee)
+}
+
+func TestErroringFeeSource(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 1},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+		FeeSource:     erroringFeeSource{},
+	}
+
+	err := tx.Build()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "fee stats unavailable")
+	}
+}
+
+func TestFeeOverflow(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 1},
+		Operations:    []Operation{&Inflation{}, &Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+		BaseFee:       math.MaxUint32/2 + 1,
+	}
+
+	err := tx.Build()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "fee overflows uint32")
+	}
+}
+
+func TestFeeNoOverflow(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 1},
+		Operations:    []Operation{&Inflation{}, &Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+		BaseFee:       math.MaxUint32 / 2,
+	}
+
+	err := tx.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.Uint32(math.MaxUint32-1), tx.xdrTransaction.Fee)
+}
+
+func TestRecommendedFee(t *testing.T) {
+	fee, err := RecommendedFee(3, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(300), fee)
+
+	fee, err = RecommendedFee(0, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(0), fee)
+
+	fee, err = RecommendedFee(1, math.MaxUint32)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(math.MaxUint32), fee)
+
+	_, err = RecommendedFee(2, math.MaxUint32)
+	assert.Error(t, err)
+
+	_, err = RecommendedFee(-1, 100)
+	assert.Error(t, err)
+}
@@ -8,7 +8,8 @@ import (
 // Inflation represents the Stellar inflation operation. See
 // https://www.stellar.org/developers/guides/concepts/list-of-operations.html
 type Inflation struct {
-	xdrOp struct{}
+	SourceAccount string
+	xdrOp         struct{}
 }
 
 // BuildXDR for Inflation returns a fully configured XDR Operation.
@@ -19,5 +20,26 @@ func (inf *Inflation) BuildXDR() (xdr.Operation, error) {
 		return xdr.Operation{}, errors.Wrap(err, "Failed to build XDR OperationBody")
 	}
 
-	return xdr.Operation{Body: body}, nil
+	op := xdr.Operation{Body: body}
+	err = setOpSourceAccount(&op, inf.SourceAccount)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to set source account")
+	}
+
+	return op, nil
+}
+
+// GetSourceAccount returns the source account of the Inflation operation, if one is set.
+func (inf *Inflation) GetSourceAccount() string {
+	return inf.SourceAccount
+}
+
+// FromXDR for Inflation initialises the operation from an XDR Operation.
+func (inf *Inflation) FromXDR(xdrOp xdr.Operation) error {
+	if xdrOp.Body.Type != xdr.OperationTypeInflation {
+		return errors.New("Operation is not an inflation")
+	}
+
+	inf.SourceAccount = opSourceAccountFromXDR(xdrOp)
+	return nil
 }
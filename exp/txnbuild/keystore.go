@@ -0,0 +1,58 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+// KeyStore signs transaction hashes on behalf of accounts whose secret keys are held
+// elsewhere, such as in a key management service.
+type KeyStore interface {
+	Sign(accountID string, hash [32]byte) ([]byte, error)
+}
+
+// SignWithKeyStore signs a previously built Transaction with the keys for the given accounts,
+// fetching each signature from the KeyStore. The signatures are only added once all of them
+// have been fetched and checked, so on error the Transaction is left unchanged.
+func (tx *Transaction) SignWithKeyStore(store KeyStore, accountIDs ...string) error {
+	hash, err := tx.Hash()
+	if err != nil {
+		return errors.Wrap(err, "Failed to hash transaction")
+	}
+
+	var signatures []xdr.DecoratedSignature
+	for _, accountID := range accountIDs {
+		kp, err := keypair.Parse(accountID)
+		if err != nil {
+			return errors.Wrapf(err, "Invalid account ID %s", accountID)
+		}
+		if _, ok := kp.(*keypair.FromAddress); !ok {
+			return errors.Errorf("Invalid account ID %s: expected an address", accountID)
+		}
+
+		sig, err := store.Sign(accountID, hash)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to sign transaction for %s", accountID)
+		}
+		if len(sig) != ed25519.SignatureSize {
+			return errors.Errorf("Invalid signature from key store for %s: signature must be %d bytes, got %d", accountID, ed25519.SignatureSize, len(sig))
+		}
+
+		signatures = append(signatures, xdr.DecoratedSignature{
+			Hint:      xdr.SignatureHint(kp.Hint()),
+			Signature: xdr.Signature(sig),
+		})
+	}
+
+	for _, sig := range signatures {
+		err = tx.AddDecoratedSignature(sig)
+		if err != nil {
+			return errors.Wrap(err, "Invalid signature from key store")
+		}
+	}
+
+	return nil
+}
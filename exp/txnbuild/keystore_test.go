@@ -0,0 +1,69 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/support/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockKeyStore struct {
+	keys map[string]*keypair.Full
+	// truncated is an account whose signatures are returned one byte short
+	truncated string
+}
+
+func (ks mockKeyStore) Sign(accountID string, hash [32]byte) ([]byte, error) {
+	kp, ok := ks.keys[accountID]
+	if !ok {
+		return nil, errors.New("key not found")
+	}
+	sig, err := kp.Sign(hash[:])
+	if err != nil || accountID != ks.truncated {
+		return sig, err
+	}
+	return sig[:len(sig)-1], nil
+}
+
+func TestSignWithKeyStore(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+	store := mockKeyStore{keys: map[string]*keypair.Full{kp0.Address(): kp0, kp1.Address(): kp1}}
+	newTx := func() Transaction {
+		tx := Transaction{
+			SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+			Operations:    []Operation{&Inflation{}},
+			Network:       network.TestNetworkPassphrase,
+		}
+		err := tx.Build()
+		assert.Nil(t, err)
+		return tx
+	}
+
+	tx := newTx()
+	err := tx.SignWithKeyStore(store, kp0.Address(), kp1.Address())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(tx.xdrEnvelope.Signatures))
+	assert.Nil(t, tx.VerifySignatures(kp0, kp1))
+
+	unknown := newTx()
+	err = unknown.SignWithKeyStore(store, kp0.Address(), "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "key not found")
+	}
+	assert.Nil(t, unknown.xdrEnvelope, "No signatures should be added when the store fails")
+
+	invalid := newTx()
+	err = invalid.SignWithKeyStore(store, "GBADADDRESS")
+	assert.Error(t, err)
+
+	store.truncated = kp1.Address()
+	truncated := newTx()
+	err = truncated.SignWithKeyStore(store, kp0.Address(), kp1.Address())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "signature must be 64 bytes, got 63")
+	}
+	assert.Nil(t, truncated.xdrEnvelope, "No signatures should be added when one is invalid")
+}
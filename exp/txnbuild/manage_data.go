@@ -0,0 +1,109 @@
+package txnbuild
+
+import (
+	"crypto/rand"
+	"unicode/utf8"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// ManageData represents the Stellar manage data operation. A nil Value deletes the data
+// entry with the given Name. See
+// https://www.stellar.org/developers/guides/concepts/list-of-operations.html
+type ManageData struct {
+	Name          string
+	Value         []byte
+	SourceAccount string
+	xdrOp         xdr.ManageDataOp
+}
+
+// NewNonceDataOp returns a ManageData operation that sets the named data entry to 32 random
+// bytes. This gives a Transaction a unique marker, for example for tracking.
+func NewNonceDataOp(name string) (*ManageData, error) {
+	if name == "" || len(name) > 64 {
+		return nil, errors.New("Data name must be between 1 and 64 bytes")
+	}
+
+	nonce := make([]byte, 32)
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to generate random nonce")
+	}
+
+	return &ManageData{Name: name, Value: nonce}, nil
+}
+
+// SetTextValue sets the Value of the ManageData operation to the UTF-8 bytes of s, for data
+// entries intended to be displayed as text. It returns an error, leaving Value unchanged, if
+// s is empty, is not valid UTF-8 or is longer than 64 bytes.
+func (md *ManageData) SetTextValue(s string) error {
+	if s == "" {
+		return errors.New("Data text value can't be empty; use a nil Value to delete the entry")
+	}
+	if !utf8.ValidString(s) {
+		return errors.New("Data text value must be valid UTF-8")
+	}
+	if len(s) > 64 {
+		return errors.Errorf("Data text value must be 64 bytes or less, got %d", len(s))
+	}
+
+	md.Value = []byte(s)
+	return nil
+}
+
+// BuildXDR for ManageData returns a fully configured XDR Operation.
+func (md *ManageData) BuildXDR() (xdr.Operation, error) {
+	if md.Name == "" {
+		return xdr.Operation{}, errors.New("Data name can't be empty")
+	}
+	if len(md.Name) > 64 {
+		return xdr.Operation{}, errors.New("Data name must be 64 bytes or less")
+	}
+	md.xdrOp.DataName = xdr.String64(md.Name)
+
+	if md.Value != nil {
+		if len(md.Value) > 64 {
+			return xdr.Operation{}, errors.New("Data value must be 64 bytes or less")
+		}
+		xdrDataValue := xdr.DataValue(md.Value)
+		md.xdrOp.DataValue = &xdrDataValue
+	} else {
+		md.xdrOp.DataValue = nil
+	}
+
+	opType := xdr.OperationTypeManageData
+	body, err := xdr.NewOperationBody(opType, md.xdrOp)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to build XDR OperationBody")
+	}
+
+	op := xdr.Operation{Body: body}
+	err = setOpSourceAccount(&op, md.SourceAccount)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to set source account")
+	}
+
+	return op, nil
+}
+
+// GetSourceAccount returns the source account of the ManageData operation, if one is set.
+func (md *ManageData) GetSourceAccount() string {
+	return md.SourceAccount
+}
+
+// FromXDR for ManageData initialises the operation from an XDR Operation.
+func (md *ManageData) FromXDR(xdrOp xdr.Operation) error {
+	result, ok := xdrOp.Body.GetManageDataOp()
+	if !ok {
+		return errors.New("Operation is not a manage data")
+	}
+
+	md.Name = string(result.DataName)
+	md.Value = nil
+	if result.DataValue != nil {
+		md.Value = []byte(*result.DataValue)
+	}
+	md.SourceAccount = opSourceAccountFromXDR(xdrOp)
+	return nil
+}
@@ -0,0 +1,119 @@
+package txnbuild
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManageData(t *testing.T) {
+	setData := ManageData{Name: "config", Value: []byte("on")}
+	xdrOp, err := setData.BuildXDR()
+	assert.Nil(t, err)
+	op := xdrOp.Body.MustManageDataOp()
+	assert.Equal(t, xdr.String64("config"), op.DataName)
+	assert.Equal(t, xdr.DataValue("on"), *op.DataValue)
+
+	clearData := ManageData{Name: "config"}
+	xdrOp, err = clearData.BuildXDR()
+	assert.Nil(t, err)
+	assert.Nil(t, xdrOp.Body.MustManageDataOp().DataValue)
+}
+
+func TestManageDataRebuildWithoutValue(t *testing.T) {
+	data := ManageData{Name: "config", Value: []byte("on")}
+	xdrOp, err := data.BuildXDR()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.DataValue("on"), *xdrOp.Body.MustManageDataOp().DataValue)
+
+	data.Value = nil
+	xdrOp, err = data.BuildXDR()
+	assert.Nil(t, err)
+	assert.Nil(t, xdrOp.Body.MustManageDataOp().DataValue, "Rebuilding without a value should delete the entry")
+}
+
+func TestManageDataEmptyName(t *testing.T) {
+	setData := ManageData{Value: []byte("on")}
+	_, err := setData.BuildXDR()
+	assert.EqualError(t, err, "Data name can't be empty")
+
+	clearData := ManageData{}
+	_, err = clearData.BuildXDR()
+	assert.EqualError(t, err, "Data name can't be empty")
+
+	validName := ManageData{Name: "c", Value: []byte("on")}
+	_, err = validName.BuildXDR()
+	assert.Nil(t, err)
+}
+
+func TestDuplicateDataNames(t *testing.T) {
+	newTx := func(ops ...Operation) *Transaction {
+		tx := newTestTx(ops...)
+		tx.RejectDuplicateDataNames = true
+		return tx
+	}
+
+	duplicate := newTx(
+		&ManageData{Name: "config", Value: []byte("on")},
+		&ManageData{Name: "config"},
+	)
+	err := duplicate.Build()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `"config"`)
+	}
+
+	duplicate.RejectDuplicateDataNames = false
+	err = duplicate.Build()
+	assert.Nil(t, err, "Duplicate names are only rejected when enabled")
+
+	distinct := newTx(
+		&ManageData{Name: "config", Value: []byte("on")},
+		&ManageData{Name: "version", Value: []byte("2")},
+	)
+	err = distinct.Build()
+	assert.Nil(t, err)
+}
+
+func TestNewNonceDataOp(t *testing.T) {
+	first, err := NewNonceDataOp("nonce")
+	assert.Nil(t, err)
+	assert.Equal(t, "nonce", first.Name)
+	assert.Equal(t, 32, len(first.Value))
+
+	second, err := NewNonceDataOp("nonce")
+	assert.Nil(t, err)
+	assert.NotEqual(t, first.Value, second.Value, "Each call should use a new random value")
+
+	_, err = first.BuildXDR()
+	assert.Nil(t, err)
+
+	_, err = NewNonceDataOp("")
+	assert.Error(t, err)
+
+	_, err = NewNonceDataOp(strings.Repeat("n", 65))
+	assert.Error(t, err)
+}
+
+func TestManageDataSetTextValue(t *testing.T) {
+	md := ManageData{Name: "greeting"}
+	err := md.SetTextValue("héllo wörld")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("héllo wörld"), md.Value)
+
+	xdrOp, err := md.BuildXDR()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.DataValue("héllo wörld"), *xdrOp.Body.MustManageDataOp().DataValue)
+
+	// 33 two-byte runes is 66 bytes, over the limit despite being 33 characters
+	err = md.SetTextValue(strings.Repeat("é", 33))
+	assert.Error(t, err)
+	assert.Equal(t, []byte("héllo wörld"), md.Value, "Value should be unchanged on error")
+
+	err = md.SetTextValue("\xff\xfe")
+	assert.Error(t, err)
+
+	err = md.SetTextValue("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,99 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/price"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// ManageSellOffer represents the Stellar manage offer operation. An OfferID of 0 creates a
+// new offer; otherwise the existing offer with that ID is updated, or deleted if Amount is
+// zero. See https://www.stellar.org/developers/guides/concepts/list-of-operations.html
+type ManageSellOffer struct {
+	Selling       Asset
+	Buying        Asset
+	Amount        string
+	Price         string
+	OfferID       int64
+	SourceAccount string
+	xdrOp         xdr.ManageOfferOp
+}
+
+// BuildXDR for ManageSellOffer returns a fully configured XDR Operation.
+func (mo *ManageSellOffer) BuildXDR() (xdr.Operation, error) {
+	if mo.OfferID < 0 {
+		return xdr.Operation{}, errors.Errorf("Offer ID can't be negative: %d", mo.OfferID)
+	}
+	mo.xdrOp.OfferId = xdr.Uint64(mo.OfferID)
+
+	var err error
+	mo.xdrOp.Selling, err = mo.Selling.ToXDR()
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to set selling asset")
+	}
+
+	mo.xdrOp.Buying, err = mo.Buying.ToXDR()
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to set buying asset")
+	}
+
+	if mo.xdrOp.Selling.Equals(mo.xdrOp.Buying) {
+		return xdr.Operation{}, errors.New("Selling and buying assets can't be identical")
+	}
+
+	mo.xdrOp.Amount, err = amount.Parse(mo.Amount)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to parse amount")
+	}
+
+	mo.xdrOp.Price, err = price.Parse(mo.Price)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to parse price")
+	}
+
+	opType := xdr.OperationTypeManageOffer
+	body, err := xdr.NewOperationBody(opType, mo.xdrOp)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to build XDR OperationBody")
+	}
+
+	op := xdr.Operation{Body: body}
+	err = setOpSourceAccount(&op, mo.SourceAccount)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to set source account")
+	}
+
+	return op, nil
+}
+
+// GetSourceAccount returns the source account of the ManageSellOffer operation, if one is set.
+func (mo *ManageSellOffer) GetSourceAccount() string {
+	return mo.SourceAccount
+}
+
+// FromXDR for ManageSellOffer initialises the operation from an XDR Operation.
+func (mo *ManageSellOffer) FromXDR(xdrOp xdr.Operation) error {
+	result, ok := xdrOp.Body.GetManageOfferOp()
+	if !ok {
+		return errors.New("Operation is not a manage offer")
+	}
+
+	selling, err := assetFromXDR(result.Selling)
+	if err != nil {
+		return errors.Wrap(err, "Failed to decode selling asset")
+	}
+
+	buying, err := assetFromXDR(result.Buying)
+	if err != nil {
+		return errors.Wrap(err, "Failed to decode buying asset")
+	}
+
+	mo.Selling = selling
+	mo.Buying = buying
+	mo.Amount = amount.String(result.Amount)
+	mo.Price = result.Price.String()
+	mo.OfferID = int64(result.OfferId)
+	mo.SourceAccount = opSourceAccountFromXDR(xdrOp)
+	return nil
+}
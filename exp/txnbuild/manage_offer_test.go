@@ -0,0 +1,63 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManageSellOfferOfferID(t *testing.T) {
+	selling := Asset{Code: "USD", Issuer: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"}
+
+	newOffer := ManageSellOffer{
+		Selling: selling,
+		Buying:  NewNativeAsset(),
+		Amount:  "10",
+		Price:   "0.5",
+	}
+	xdrOp, err := newOffer.BuildXDR()
+	assert.Nil(t, err)
+	op := xdrOp.Body.MustManageOfferOp()
+	assert.Equal(t, xdr.Uint64(0), op.OfferId)
+	assert.Equal(t, xdr.Int64(100000000), op.Amount)
+	assert.Equal(t, xdr.Price{N: 1, D: 2}, op.Price)
+	assert.Equal(t, xdr.AssetTypeAssetTypeNative, op.Buying.Type)
+
+	existingOffer := ManageSellOffer{
+		Selling: selling,
+		Buying:  NewNativeAsset(),
+		Amount:  "10",
+		Price:   "0.5",
+		OfferID: 42,
+	}
+	xdrOp, err = existingOffer.BuildXDR()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.Uint64(42), xdrOp.Body.MustManageOfferOp().OfferId)
+
+	negativeOffer := ManageSellOffer{
+		Selling: selling,
+		Buying:  NewNativeAsset(),
+		Amount:  "10",
+		Price:   "0.5",
+		OfferID: -1,
+	}
+	_, err = negativeOffer.BuildXDR()
+	assert.Error(t, err)
+}
+
+func TestManageSellOfferIdenticalAssets(t *testing.T) {
+	usd := Asset{Code: "USD", Issuer: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"}
+
+	distinct := ManageSellOffer{Selling: usd, Buying: NewNativeAsset(), Amount: "10", Price: "0.5"}
+	_, err := distinct.BuildXDR()
+	assert.Nil(t, err)
+
+	identical := ManageSellOffer{Selling: usd, Buying: usd, Amount: "10", Price: "0.5"}
+	_, err = identical.BuildXDR()
+	assert.EqualError(t, err, "Selling and buying assets can't be identical")
+
+	native := ManageSellOffer{Selling: NewNativeAsset(), Buying: NewNativeAsset(), Amount: "10", Price: "0.5"}
+	_, err = native.BuildXDR()
+	assert.Error(t, err)
+}
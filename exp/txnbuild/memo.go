@@ -0,0 +1,171 @@
+package txnbuild
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// MemoTextMaxLength is the maximum number of bytes allowed for a text memo.
+const MemoTextMaxLength = 28
+
+// Memo represents the superset of all memo types. See
+// https://www.stellar.org/developers/guides/concepts/transactions.html#memo
+type Memo interface {
+	ToXDR() (xdr.Memo, error)
+}
+
+// MemoText is used to send human messages of up to 28 bytes of ASCII/UTF-8.
+type MemoText string
+
+// MemoID is an identifier representing the transaction originator.
+type MemoID uint64
+
+// NewMemoIDFromString returns the MemoID represented by a decimal string, such as one read
+// from a configuration file.
+func NewMemoIDFromString(s string) (MemoID, error) {
+	id, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Invalid memo ID %q", s)
+	}
+
+	return MemoID(id), nil
+}
+
+// MemoHash is a hash representing a reference to another transaction.
+type MemoHash [32]byte
+
+// MemoReturn is a hash representing the hash of the transaction the sender is refunding.
+type MemoReturn [32]byte
+
+// ToXDR for MemoText returns an XDR object representation of a Memo of the same type.
+func (mt MemoText) ToXDR() (xdr.Memo, error) {
+	if len(mt) > MemoTextMaxLength {
+		return xdr.Memo{}, errors.Errorf("Memo text can't be longer than %d bytes", MemoTextMaxLength)
+	}
+
+	return xdr.NewMemo(xdr.MemoTypeMemoText, string(mt))
+}
+
+// ToXDR for MemoID returns an XDR object representation of a Memo of the same type.
+func (mid MemoID) ToXDR() (xdr.Memo, error) {
+	return xdr.NewMemo(xdr.MemoTypeMemoId, xdr.Uint64(mid))
+}
+
+// ToXDR for MemoHash returns an XDR object representation of a Memo of the same type.
+func (mh MemoHash) ToXDR() (xdr.Memo, error) {
+	return xdr.NewMemo(xdr.MemoTypeMemoHash, xdr.Hash(mh))
+}
+
+// ToXDR for MemoReturn returns an XDR object representation of a Memo of the same type.
+func (mr MemoReturn) ToXDR() (xdr.Memo, error) {
+	return xdr.NewMemo(xdr.MemoTypeMemoReturn, xdr.Hash(mr))
+}
+
+// RequireMemoFor returns an error if the Transaction sends value to any of the flagged
+// destinations without a memo being set. Destinations such as exchanges use the memo to
+// identify the recipient of a deposit, so omitting it can result in lost funds (SEP-29).
+func (tx *Transaction) RequireMemoFor(destinations map[string]bool) error {
+	if tx.Memo != nil {
+		return nil
+	}
+
+	for _, destination := range tx.Destinations() {
+		if destinations[destination] {
+			return errors.Errorf("destination %s requires a memo", destination)
+		}
+	}
+
+	return nil
+}
+
+// WarnIfMissingMemo returns an error if the Transaction contains a payment but no memo.
+// Payments to custodial destinations such as exchanges conventionally carry a memo, so this
+// flags transactions that are likely missing one. Unlike RequireMemoFor, it applies to every
+// payment destination, and callers opt in by calling it before submission.
+func (tx *Transaction) WarnIfMissingMemo() error {
+	if tx.Memo != nil {
+		return nil
+	}
+
+	for i, op := range tx.Operations {
+		if _, ok := op.(*Payment); ok {
+			return errors.Errorf("operation %d is a payment but the transaction has no memo", i)
+		}
+	}
+
+	return nil
+}
+
+// memoFromXDR returns the Memo represented by an XDR memo, or nil if it has none.
+func memoFromXDR(xdrMemo xdr.Memo) Memo {
+	switch xdrMemo.Type {
+	case xdr.MemoTypeMemoText:
+		return MemoText(xdrMemo.MustText())
+	case xdr.MemoTypeMemoId:
+		return MemoID(xdrMemo.MustId())
+	case xdr.MemoTypeMemoHash:
+		return MemoHash(xdrMemo.MustHash())
+	case xdr.MemoTypeMemoReturn:
+		return MemoReturn(xdrMemo.MustRetHash())
+	default:
+		return nil
+	}
+}
+
+// describeMemo returns a short, human readable description of a Memo.
+func describeMemo(memo Memo) string {
+	switch m := memo.(type) {
+	case nil:
+		return "none"
+	case MemoText:
+		return fmt.Sprintf("text %q", string(m))
+	case MemoID:
+		return fmt.Sprintf("id %d", uint64(m))
+	case MemoHash:
+		return fmt.Sprintf("hash %x", m[:])
+	case MemoReturn:
+		return fmt.Sprintf("return %x", m[:])
+	default:
+		return fmt.Sprintf("%T", memo)
+	}
+}
+
+// memosEqual returns true if the two memos, either of which may be nil, encode to the same
+// XDR. Comparing the XDR works for any Memo implementation, including ones that aren't
+// comparable with ==.
+func memosEqual(a, b Memo) (bool, error) {
+	aBytes, err := memoBytes(a)
+	if err != nil {
+		return false, err
+	}
+	bBytes, err := memoBytes(b)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(aBytes, bBytes), nil
+}
+
+// memoBytes returns the binary XDR encoding of a memo, or of no memo if it is nil.
+func memoBytes(memo Memo) ([]byte, error) {
+	var xdrMemo xdr.Memo
+	if memo != nil {
+		var err error
+		xdrMemo, err = memo.ToXDR()
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to build memo")
+		}
+	}
+
+	var memoBytes bytes.Buffer
+	_, err := xdr.Marshal(&memoBytes, xdrMemo)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to marshal XDR")
+	}
+
+	return memoBytes.Bytes(), nil
+}
@@ -0,0 +1,108 @@
+package txnbuild
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoText(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 1},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+		Memo:          MemoText("Twas brillig"),
+	}
+
+	err := tx.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.MemoTypeMemoText, tx.xdrTransaction.Memo.Type)
+	assert.Equal(t, "Twas brillig", tx.xdrTransaction.Memo.MustText())
+}
+
+func TestMemoTextTooLong(t *testing.T) {
+	_, err := MemoText(strings.Repeat("a", MemoTextMaxLength+1)).ToXDR()
+	assert.Error(t, err)
+}
+
+func TestMemoID(t *testing.T) {
+	memo, err := MemoID(12345).ToXDR()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.Uint64(12345), memo.MustId())
+}
+
+func TestNewMemoIDFromString(t *testing.T) {
+	memo, err := NewMemoIDFromString("18446744073709551615")
+	assert.Nil(t, err)
+	assert.Equal(t, MemoID(18446744073709551615), memo)
+
+	_, err = NewMemoIDFromString("18446744073709551616")
+	assert.Error(t, err, "Overflow should be rejected")
+
+	_, err = NewMemoIDFromString("-1")
+	assert.Error(t, err)
+
+	_, err = NewMemoIDFromString("12ab")
+	assert.Error(t, err)
+}
+
+func TestRequireMemoFor(t *testing.T) {
+	kp0 := newKeypair0()
+	exchange := "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"
+	flagged := map[string]bool{exchange: true}
+
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 1},
+		Operations:    []Operation{&Payment{Destination: exchange, Amount: "10"}},
+		Network:       network.TestNetworkPassphrase,
+	}
+
+	err := tx.RequireMemoFor(flagged)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), exchange)
+	}
+
+	tx.Memo = MemoID(1)
+	err = tx.RequireMemoFor(flagged)
+	assert.Nil(t, err)
+}
+
+func TestRequireMemoForUnflaggedDestination(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 1},
+		Operations: []Operation{&Payment{
+			Destination: "GCCOBXW2XQNUSL467IEILE6MMCNRR66SSVL4YQADUNYYNUVREF3FIV2Z",
+			Amount:      "10",
+		}},
+		Network: network.TestNetworkPassphrase,
+	}
+
+	err := tx.RequireMemoFor(map[string]bool{"GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H": true})
+	assert.Nil(t, err)
+}
+
+func TestWarnIfMissingMemo(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 1},
+		Operations: []Operation{
+			&BumpSequence{BumpTo: 10},
+			&Payment{Destination: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H", Amount: "10"},
+		},
+		Network: network.TestNetworkPassphrase,
+	}
+
+	err := tx.WarnIfMissingMemo()
+	assert.EqualError(t, err, "operation 1 is a payment but the transaction has no memo")
+
+	tx.Memo = MemoText("deposit")
+	assert.Nil(t, tx.WarnIfMissingMemo())
+
+	noPayments := Transaction{Operations: []Operation{&BumpSequence{BumpTo: 10}}}
+	assert.Nil(t, noPayments.WarnIfMissingMemo())
+}
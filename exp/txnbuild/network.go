@@ -0,0 +1,11 @@
+package txnbuild
+
+// Network is the passphrase identifying the Stellar network a Transaction is intended for.
+// Besides the public and test network passphrases, any passphrase used by a private or
+// standalone network may be given.
+type Network string
+
+// NewNetwork returns the Network identified by the given passphrase.
+func NewNetwork(passphrase string) Network {
+	return Network(passphrase)
+}
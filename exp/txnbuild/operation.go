@@ -1,10 +1,158 @@
 package txnbuild
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/xdr"
 )
 
 // Operation represents the operation types of the Stellar network.
 type Operation interface {
 	BuildXDR() (xdr.Operation, error)
+	FromXDR(xdrOp xdr.Operation) error
+	GetSourceAccount() string
+}
+
+// setOpSourceAccount sets the source account of an XDR operation, if one is given. When no
+// source account is given, the operation uses the Transaction's source account.
+func setOpSourceAccount(xdrOp *xdr.Operation, sourceAccount string) error {
+	if sourceAccount == "" {
+		return nil
+	}
+
+	var xdrAccountID xdr.AccountId
+	err := xdrAccountID.SetAddress(sourceAccount)
+	if err != nil {
+		return errors.Wrap(err, withValue("Failed to set operation source account", sourceAccount))
+	}
+	xdrOp.SourceAccount = &xdrAccountID
+
+	return nil
+}
+
+// opSourceAccountFromXDR returns the address of an XDR operation's source account, or an
+// empty string if the operation has none.
+func opSourceAccountFromXDR(xdrOp xdr.Operation) string {
+	if xdrOp.SourceAccount == nil {
+		return ""
+	}
+	return xdrOp.SourceAccount.Address()
+}
+
+// operationFromXDR returns the Operation represented by an XDR operation.
+func operationFromXDR(xdrOp xdr.Operation) (Operation, error) {
+	var op Operation
+	switch xdrOp.Body.Type {
+	case xdr.OperationTypeCreateAccount:
+		op = &CreateAccount{}
+	case xdr.OperationTypePayment:
+		op = &Payment{}
+	case xdr.OperationTypeManageOffer:
+		op = &ManageSellOffer{}
+	case xdr.OperationTypeSetOptions:
+		op = &SetOptions{}
+	case xdr.OperationTypeChangeTrust:
+		op = &ChangeTrust{}
+	case xdr.OperationTypeAccountMerge:
+		op = &AccountMerge{}
+	case xdr.OperationTypeInflation:
+		op = &Inflation{}
+	case xdr.OperationTypeManageData:
+		op = &ManageData{}
+	case xdr.OperationTypeBumpSequence:
+		op = &BumpSequence{}
+	case xdr.OperationTypeAllowTrust:
+		op = &AllowTrust{}
+	default:
+		return nil, errors.Errorf("Unsupported operation type %s", xdrOp.Body.Type)
+	}
+
+	err := op.FromXDR(xdrOp)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("Failed to decode operation %T", op))
+	}
+
+	return op, nil
+}
+
+// operationType returns the XDR operation type of an Operation. It returns an error for
+// Operation implementations from outside this package.
+func operationType(op Operation) (xdr.OperationType, error) {
+	switch op.(type) {
+	case *CreateAccount:
+		return xdr.OperationTypeCreateAccount, nil
+	case *Payment:
+		return xdr.OperationTypePayment, nil
+	case *ManageSellOffer:
+		return xdr.OperationTypeManageOffer, nil
+	case *SetOptions:
+		return xdr.OperationTypeSetOptions, nil
+	case *ChangeTrust:
+		return xdr.OperationTypeChangeTrust, nil
+	case *AccountMerge:
+		return xdr.OperationTypeAccountMerge, nil
+	case *Inflation:
+		return xdr.OperationTypeInflation, nil
+	case *ManageData:
+		return xdr.OperationTypeManageData, nil
+	case *BumpSequence:
+		return xdr.OperationTypeBumpSequence, nil
+	case *AllowTrust:
+		return xdr.OperationTypeAllowTrust, nil
+	default:
+		return 0, errors.Errorf("Unknown operation %T", op)
+	}
+}
+
+// describeOperation returns a short, human readable description of an Operation, starting
+// with its type.
+func describeOperation(op Operation) string {
+	var detail string
+	switch o := op.(type) {
+	case *CreateAccount:
+		detail = fmt.Sprintf("%s with starting balance %s", o.Destination, o.Amount)
+	case *Payment:
+		detail = fmt.Sprintf("%s %s to %s", o.Amount, o.Asset, o.Destination)
+	case *ManageSellOffer:
+		detail = fmt.Sprintf("sell %s %s for %s at price %s", o.Amount, o.Selling, o.Buying, o.Price)
+		if o.OfferID != 0 {
+			detail += fmt.Sprintf(" (offer %d)", o.OfferID)
+		}
+	case *ChangeTrust:
+		limit := o.Limit
+		if limit == "" {
+			limit = "max"
+		}
+		detail = fmt.Sprintf("%s with limit %s", o.Line, limit)
+	case *AllowTrust:
+		action := "authorize"
+		if !o.Authorize {
+			action = "deauthorize"
+		}
+		detail = fmt.Sprintf("%s %s for %s", action, o.Trustor, o.Type.Code)
+	case *AccountMerge:
+		detail = fmt.Sprintf("into %s", o.Destination)
+	case *ManageData:
+		if o.Value == nil {
+			detail = fmt.Sprintf("delete %q", o.Name)
+		} else {
+			detail = fmt.Sprintf("set %q", o.Name)
+		}
+	case *BumpSequence:
+		detail = fmt.Sprintf("to %d", o.BumpTo)
+	}
+
+	description := fmt.Sprintf("%T", op)
+	if opType, err := operationType(op); err == nil {
+		description = strings.TrimPrefix(opType.String(), "OperationType")
+	}
+	if detail != "" {
+		description += ": " + detail
+	}
+	if source := op.GetSourceAccount(); source != "" {
+		description += fmt.Sprintf(" (source %s)", source)
+	}
+	return description
 }
@@ -6,14 +6,14 @@ import (
 	"github.com/stellar/go/xdr"
 )
 
-// Payment represents the Stellar payment operation. See
-// https://www.stellar.org/developers/guides/concepts/list-of-operations.html
+// Payment represents the Stellar payment operation. If Asset is not set, the payment is
+// made in lumens. See https://www.stellar.org/developers/guides/concepts/list-of-operations.html
 type Payment struct {
 	Destination   string
 	Amount        string
-	Asset         string // TODO: Not used yet
+	Asset         Asset
+	SourceAccount string
 	destAccountID xdr.AccountId
-	xdrAsset      xdr.Asset
 	xdrOp         xdr.PaymentOp
 }
 
@@ -21,7 +21,7 @@ type Payment struct {
 func (p *Payment) BuildXDR() (xdr.Operation, error) {
 	err := p.destAccountID.SetAddress(p.Destination)
 	if err != nil {
-		return xdr.Operation{}, errors.Wrap(err, "Failed to set destination address")
+		return xdr.Operation{}, errors.Wrap(err, withValue("Failed to set destination address", p.Destination))
 	}
 	p.xdrOp.Destination = p.destAccountID
 
@@ -30,8 +30,7 @@ func (p *Payment) BuildXDR() (xdr.Operation, error) {
 		return xdr.Operation{}, errors.Wrap(err, "Failed to parse amount")
 	}
 
-	// TODO: Generalise to non-native currencies
-	p.xdrAsset, err = xdr.NewAsset(xdr.AssetTypeAssetTypeNative, nil)
+	p.xdrOp.Asset, err = p.Asset.ToXDR()
 	if err != nil {
 		return xdr.Operation{}, errors.Wrap(err, "Failed to set asset type")
 	}
@@ -42,5 +41,99 @@ func (p *Payment) BuildXDR() (xdr.Operation, error) {
 		return xdr.Operation{}, errors.Wrap(err, "Failed to build XDR OperationBody")
 	}
 
-	return xdr.Operation{Body: body}, nil
+	op := xdr.Operation{Body: body}
+	err = setOpSourceAccount(&op, p.SourceAccount)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to set source account")
+	}
+
+	return op, nil
+}
+
+// GetSourceAccount returns the source account of the Payment operation, if one is set.
+func (p *Payment) GetSourceAccount() string {
+	return p.SourceAccount
+}
+
+// FromXDR for Payment initialises the operation from an XDR Operation.
+func (p *Payment) FromXDR(xdrOp xdr.Operation) error {
+	result, ok := xdrOp.Body.GetPaymentOp()
+	if !ok {
+		return errors.New("Operation is not a payment")
+	}
+
+	asset, err := assetFromXDR(result.Asset)
+	if err != nil {
+		return errors.Wrap(err, "Failed to decode asset")
+	}
+
+	p.Asset = asset
+	p.Destination = result.Destination.Address()
+	p.Amount = amount.String(result.Amount)
+	p.SourceAccount = opSourceAccountFromXDR(xdrOp)
+	return nil
+}
+
+// NewPaymentTx returns a built Transaction containing a single payment of amt of asset from
+// source to dest, carrying the given memo. A memo of nil leaves the Transaction without a memo,
+// and a baseFee of 0 uses the default base fee.
+func NewPaymentTx(source Account, network Network, dest, amt string, asset Asset, memo Memo, baseFee uint32) (*Transaction, error) {
+	tx := Transaction{
+		SourceAccount: source,
+		Operations: []Operation{&Payment{
+			Destination: dest,
+			Amount:      amt,
+			Asset:       asset,
+		}},
+		BaseFee: uint64(baseFee),
+		Network: network,
+		Memo:    memo,
+	}
+
+	err := tx.Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to build payment transaction")
+	}
+
+	return &tx, nil
+}
+
+// BuildPaymentBatch splits payments across as many built Transactions as needed, each
+// containing at most perTx operations. The Transactions use consecutive sequence numbers
+// following source's, so they must be submitted in the order they are returned.
+func BuildPaymentBatch(source Account, network Network, payments []Payment, baseFee uint32, perTx int) ([]*Transaction, error) {
+	if perTx < 1 || perTx > MaxOperationsPerTransaction {
+		return nil, errors.Errorf("operations per transaction must be between 1 and %d", MaxOperationsPerTransaction)
+	}
+
+	var txs []*Transaction
+	for start := 0; start < len(payments); start += perTx {
+		end := start + perTx
+		if end > len(payments) {
+			end = len(payments)
+		}
+
+		ops := make([]Operation, 0, end-start)
+		for i := start; i < end; i++ {
+			payment := payments[i]
+			ops = append(ops, &payment)
+		}
+
+		tx := &Transaction{
+			SourceAccount: Account{
+				ID:             source.ID,
+				SequenceNumber: source.SequenceNumber + xdr.SequenceNumber(len(txs)),
+			},
+			Operations: ops,
+			BaseFee:    uint64(baseFee),
+			Network:    network,
+		}
+		err := tx.Build()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to build transaction %d of payment batch", len(txs))
+		}
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
 }
@@ -0,0 +1,204 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// TransactionResult is the decoded result of a submitted Transaction. Result codes use the
+// same names as Horizon, such as "tx_failed" and "op_underfunded".
+type TransactionResult struct {
+	FeeCharged int64
+	Code       string
+	// OperationResults holds the result code of each operation, in order. It is empty when
+	// the Transaction failed before its operations were applied.
+	OperationResults []string
+}
+
+// ParseTransactionResult decodes a base 64 XDR transaction result, as returned after
+// submitting a Transaction, into its transaction and operation result codes.
+func ParseTransactionResult(resultXDR string) (TransactionResult, error) {
+	var xdrResult xdr.TransactionResult
+	err := xdr.SafeUnmarshalBase64(resultXDR, &xdrResult)
+	if err != nil {
+		return TransactionResult{}, errors.Wrap(err, "Failed to unmarshal transaction result XDR")
+	}
+
+	result := TransactionResult{
+		FeeCharged: int64(xdrResult.FeeCharged),
+		Code:       transactionResultCodeString(xdrResult.Result.Code),
+	}
+	if opResults, ok := xdrResult.Result.GetResults(); ok {
+		for _, opResult := range opResults {
+			result.OperationResults = append(result.OperationResults, OperationResultString(opResult))
+		}
+	}
+
+	return result, nil
+}
+
+var transactionResultCodes = map[xdr.TransactionResultCode]string{
+	xdr.TransactionResultCodeTxSuccess:             "tx_success",
+	xdr.TransactionResultCodeTxFailed:              "tx_failed",
+	xdr.TransactionResultCodeTxTooEarly:            "tx_too_early",
+	xdr.TransactionResultCodeTxTooLate:             "tx_too_late",
+	xdr.TransactionResultCodeTxMissingOperation:    "tx_missing_operation",
+	xdr.TransactionResultCodeTxBadSeq:              "tx_bad_seq",
+	xdr.TransactionResultCodeTxBadAuth:             "tx_bad_auth",
+	xdr.TransactionResultCodeTxInsufficientBalance: "tx_insufficient_balance",
+	xdr.TransactionResultCodeTxNoAccount:           "tx_no_source_account",
+	xdr.TransactionResultCodeTxInsufficientFee:     "tx_insufficient_fee",
+	xdr.TransactionResultCodeTxBadAuthExtra:        "tx_bad_auth_extra",
+	xdr.TransactionResultCodeTxInternalError:       "tx_internal_error",
+}
+
+// transactionResultCodeString returns the name of a transaction result code.
+func transactionResultCodeString(code xdr.TransactionResultCode) string {
+	if s, ok := transactionResultCodes[code]; ok {
+		return s
+	}
+	return "tx_unknown"
+}
+
+var operationResultCodes = map[xdr.OperationResultCode]string{
+	xdr.OperationResultCodeOpBadAuth:      "op_bad_auth",
+	xdr.OperationResultCodeOpNoAccount:    "op_no_source_account",
+	xdr.OperationResultCodeOpNotSupported: "op_not_supported",
+}
+
+// OperationResultString returns the Horizon name of an operation's result code, such as
+// "op_no_trust". For operations that were applied, this is the code of the operation type's
+// own result, so a payment that failed for lack of funds gives "op_underfunded". Codes of
+// operation types that txnbuild doesn't support are reported as "op_unknown".
+func OperationResultString(r xdr.OperationResult) string {
+	if r.Code != xdr.OperationResultCodeOpInner {
+		if s, ok := operationResultCodes[r.Code]; ok {
+			return s
+		}
+		return "op_unknown"
+	}
+
+	var s string
+	var ok bool
+	tr := r.MustTr()
+	switch tr.Type {
+	case xdr.OperationTypeCreateAccount:
+		s, ok = createAccountResultCodes[tr.MustCreateAccountResult().Code]
+	case xdr.OperationTypePayment:
+		s, ok = paymentResultCodes[tr.MustPaymentResult().Code]
+	case xdr.OperationTypeManageOffer:
+		s, ok = manageOfferResultCodes[tr.MustManageOfferResult().Code]
+	case xdr.OperationTypeSetOptions:
+		s, ok = setOptionsResultCodes[tr.MustSetOptionsResult().Code]
+	case xdr.OperationTypeChangeTrust:
+		s, ok = changeTrustResultCodes[tr.MustChangeTrustResult().Code]
+	case xdr.OperationTypeAllowTrust:
+		s, ok = allowTrustResultCodes[tr.MustAllowTrustResult().Code]
+	case xdr.OperationTypeAccountMerge:
+		s, ok = accountMergeResultCodes[tr.MustAccountMergeResult().Code]
+	case xdr.OperationTypeInflation:
+		s, ok = inflationResultCodes[tr.MustInflationResult().Code]
+	case xdr.OperationTypeManageData:
+		s, ok = manageDataResultCodes[tr.MustManageDataResult().Code]
+	case xdr.OperationTypeBumpSequence:
+		s, ok = bumpSequenceResultCodes[tr.MustBumpSeqResult().Code]
+	}
+	if !ok {
+		return "op_unknown"
+	}
+	return s
+}
+
+var createAccountResultCodes = map[xdr.CreateAccountResultCode]string{
+	xdr.CreateAccountResultCodeCreateAccountSuccess:      "op_success",
+	xdr.CreateAccountResultCodeCreateAccountMalformed:    "op_malformed",
+	xdr.CreateAccountResultCodeCreateAccountUnderfunded:  "op_underfunded",
+	xdr.CreateAccountResultCodeCreateAccountLowReserve:   "op_low_reserve",
+	xdr.CreateAccountResultCodeCreateAccountAlreadyExist: "op_already_exists",
+}
+
+var paymentResultCodes = map[xdr.PaymentResultCode]string{
+	xdr.PaymentResultCodePaymentSuccess:          "op_success",
+	xdr.PaymentResultCodePaymentMalformed:        "op_malformed",
+	xdr.PaymentResultCodePaymentUnderfunded:      "op_underfunded",
+	xdr.PaymentResultCodePaymentSrcNoTrust:       "op_src_no_trust",
+	xdr.PaymentResultCodePaymentSrcNotAuthorized: "op_src_not_authorized",
+	xdr.PaymentResultCodePaymentNoDestination:    "op_no_destination",
+	xdr.PaymentResultCodePaymentNoTrust:          "op_no_trust",
+	xdr.PaymentResultCodePaymentNotAuthorized:    "op_not_authorized",
+	xdr.PaymentResultCodePaymentLineFull:         "op_line_full",
+	xdr.PaymentResultCodePaymentNoIssuer:         "op_no_issuer",
+}
+
+var manageOfferResultCodes = map[xdr.ManageOfferResultCode]string{
+	xdr.ManageOfferResultCodeManageOfferSuccess:           "op_success",
+	xdr.ManageOfferResultCodeManageOfferMalformed:         "op_malformed",
+	xdr.ManageOfferResultCodeManageOfferSellNoTrust:       "op_sell_no_trust",
+	xdr.ManageOfferResultCodeManageOfferBuyNoTrust:        "op_buy_no_trust",
+	xdr.ManageOfferResultCodeManageOfferSellNotAuthorized: "sell_not_authorized",
+	xdr.ManageOfferResultCodeManageOfferBuyNotAuthorized:  "buy_not_authorized",
+	xdr.ManageOfferResultCodeManageOfferLineFull:          "op_line_full",
+	xdr.ManageOfferResultCodeManageOfferUnderfunded:       "op_underfunded",
+	xdr.ManageOfferResultCodeManageOfferCrossSelf:         "op_cross_self",
+	xdr.ManageOfferResultCodeManageOfferSellNoIssuer:      "op_sell_no_issuer",
+	xdr.ManageOfferResultCodeManageOfferBuyNoIssuer:       "buy_no_issuer",
+	xdr.ManageOfferResultCodeManageOfferNotFound:          "op_offer_not_found",
+	xdr.ManageOfferResultCodeManageOfferLowReserve:        "op_low_reserve",
+}
+
+var setOptionsResultCodes = map[xdr.SetOptionsResultCode]string{
+	xdr.SetOptionsResultCodeSetOptionsSuccess:             "op_success",
+	xdr.SetOptionsResultCodeSetOptionsLowReserve:          "op_low_reserve",
+	xdr.SetOptionsResultCodeSetOptionsTooManySigners:      "op_too_many_signers",
+	xdr.SetOptionsResultCodeSetOptionsBadFlags:            "op_bad_flags",
+	xdr.SetOptionsResultCodeSetOptionsInvalidInflation:    "op_invalid_inflation",
+	xdr.SetOptionsResultCodeSetOptionsCantChange:          "op_cant_change",
+	xdr.SetOptionsResultCodeSetOptionsUnknownFlag:         "op_unknown_flag",
+	xdr.SetOptionsResultCodeSetOptionsThresholdOutOfRange: "op_threshold_out_of_range",
+	xdr.SetOptionsResultCodeSetOptionsBadSigner:           "op_bad_signer",
+	xdr.SetOptionsResultCodeSetOptionsInvalidHomeDomain:   "op_invalid_home_domain",
+}
+
+var changeTrustResultCodes = map[xdr.ChangeTrustResultCode]string{
+	xdr.ChangeTrustResultCodeChangeTrustSuccess:      "op_success",
+	xdr.ChangeTrustResultCodeChangeTrustMalformed:    "op_malformed",
+	xdr.ChangeTrustResultCodeChangeTrustNoIssuer:     "op_no_issuer",
+	xdr.ChangeTrustResultCodeChangeTrustInvalidLimit: "op_invalid_limit",
+	xdr.ChangeTrustResultCodeChangeTrustLowReserve:   "op_low_reserve",
+}
+
+var allowTrustResultCodes = map[xdr.AllowTrustResultCode]string{
+	xdr.AllowTrustResultCodeAllowTrustSuccess:          "op_success",
+	xdr.AllowTrustResultCodeAllowTrustMalformed:        "op_malformed",
+	xdr.AllowTrustResultCodeAllowTrustNoTrustLine:      "op_no_trustline",
+	xdr.AllowTrustResultCodeAllowTrustTrustNotRequired: "op_not_required",
+	xdr.AllowTrustResultCodeAllowTrustCantRevoke:       "op_cant_revoke",
+}
+
+var accountMergeResultCodes = map[xdr.AccountMergeResultCode]string{
+	xdr.AccountMergeResultCodeAccountMergeSuccess:       "op_success",
+	xdr.AccountMergeResultCodeAccountMergeMalformed:     "op_malformed",
+	xdr.AccountMergeResultCodeAccountMergeNoAccount:     "op_no_account",
+	xdr.AccountMergeResultCodeAccountMergeImmutableSet:  "op_immutable_set",
+	xdr.AccountMergeResultCodeAccountMergeHasSubEntries: "op_has_sub_entries",
+	xdr.AccountMergeResultCodeAccountMergeSeqnumTooFar:  "op_seq_num_too_far",
+	xdr.AccountMergeResultCodeAccountMergeDestFull:      "op_dest_full",
+}
+
+var inflationResultCodes = map[xdr.InflationResultCode]string{
+	xdr.InflationResultCodeInflationSuccess: "op_success",
+	xdr.InflationResultCodeInflationNotTime: "op_not_time",
+}
+
+var manageDataResultCodes = map[xdr.ManageDataResultCode]string{
+	xdr.ManageDataResultCodeManageDataSuccess:         "op_success",
+	xdr.ManageDataResultCodeManageDataNotSupportedYet: "op_not_supported_yet",
+	xdr.ManageDataResultCodeManageDataNameNotFound:    "op_data_name_not_found",
+	xdr.ManageDataResultCodeManageDataLowReserve:      "op_low_reserve",
+	xdr.ManageDataResultCodeManageDataInvalidName:     "op_data_invalid_name",
+}
+
+var bumpSequenceResultCodes = map[xdr.BumpSequenceResultCode]string{
+	xdr.BumpSequenceResultCodeBumpSequenceSuccess: "op_success",
+	xdr.BumpSequenceResultCodeBumpSequenceBadSeq:  "op_bad_seq",
+}
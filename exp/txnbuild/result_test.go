@@ -0,0 +1,104 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPaymentResult(code xdr.PaymentResultCode) xdr.OperationResult {
+	return xdr.OperationResult{
+		Code: xdr.OperationResultCodeOpInner,
+		Tr: &xdr.OperationResultTr{
+			Type:          xdr.OperationTypePayment,
+			PaymentResult: &xdr.PaymentResult{Code: code},
+		},
+	}
+}
+
+func encodeTransactionResult(t *testing.T, code xdr.TransactionResultCode, opResults []xdr.OperationResult) string {
+	result := xdr.TransactionResult{
+		FeeCharged: 200,
+		Result:     xdr.TransactionResultResult{Code: code, Results: &opResults},
+	}
+	resultXDR, err := xdr.MarshalBase64(result)
+	assert.Nil(t, err)
+	return resultXDR
+}
+
+func TestParseTransactionResult(t *testing.T) {
+	success := encodeTransactionResult(t, xdr.TransactionResultCodeTxSuccess, []xdr.OperationResult{
+		newPaymentResult(xdr.PaymentResultCodePaymentSuccess),
+		{
+			Code: xdr.OperationResultCodeOpInner,
+			Tr: &xdr.OperationResultTr{
+				Type:          xdr.OperationTypeBumpSequence,
+				BumpSeqResult: &xdr.BumpSequenceResult{Code: xdr.BumpSequenceResultCodeBumpSequenceSuccess},
+			},
+		},
+	})
+	result, err := ParseTransactionResult(success)
+	assert.Nil(t, err)
+	assert.Equal(t, TransactionResult{
+		FeeCharged:       200,
+		Code:             "tx_success",
+		OperationResults: []string{"op_success", "op_success"},
+	}, result)
+
+	failed := encodeTransactionResult(t, xdr.TransactionResultCodeTxFailed, []xdr.OperationResult{
+		newPaymentResult(xdr.PaymentResultCodePaymentSuccess),
+		newPaymentResult(xdr.PaymentResultCodePaymentUnderfunded),
+		{Code: xdr.OperationResultCodeOpNoAccount},
+	})
+	result, err = ParseTransactionResult(failed)
+	assert.Nil(t, err)
+	assert.Equal(t, "tx_failed", result.Code)
+	assert.Equal(t, []string{"op_success", "op_underfunded", "op_no_source_account"}, result.OperationResults)
+
+	badSeq, err := xdr.MarshalBase64(xdr.TransactionResult{
+		FeeCharged: 100,
+		Result:     xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxBadSeq},
+	})
+	assert.Nil(t, err)
+	result, err = ParseTransactionResult(badSeq)
+	assert.Nil(t, err)
+	assert.Equal(t, "tx_bad_seq", result.Code)
+	assert.Empty(t, result.OperationResults)
+
+	_, err = ParseTransactionResult("not xdr")
+	assert.Error(t, err)
+}
+
+func TestOperationResultString(t *testing.T) {
+	assert.Equal(t, "op_underfunded", OperationResultString(newPaymentResult(xdr.PaymentResultCodePaymentUnderfunded)))
+	assert.Equal(t, "op_no_trust", OperationResultString(newPaymentResult(xdr.PaymentResultCodePaymentNoTrust)))
+	assert.Equal(t, "op_bad_auth", OperationResultString(xdr.OperationResult{Code: xdr.OperationResultCodeOpBadAuth}))
+
+	lowReserve := xdr.OperationResult{
+		Code: xdr.OperationResultCodeOpInner,
+		Tr: &xdr.OperationResultTr{
+			Type:                xdr.OperationTypeCreateAccount,
+			CreateAccountResult: &xdr.CreateAccountResult{Code: xdr.CreateAccountResultCodeCreateAccountLowReserve},
+		},
+	}
+	assert.Equal(t, "op_low_reserve", OperationResultString(lowReserve))
+
+	noTrustline := xdr.OperationResult{
+		Code: xdr.OperationResultCodeOpInner,
+		Tr: &xdr.OperationResultTr{
+			Type:             xdr.OperationTypeAllowTrust,
+			AllowTrustResult: &xdr.AllowTrustResult{Code: xdr.AllowTrustResultCodeAllowTrustNoTrustLine},
+		},
+	}
+	assert.Equal(t, "op_no_trustline", OperationResultString(noTrustline))
+
+	pathPayment := xdr.OperationResult{
+		Code: xdr.OperationResultCodeOpInner,
+		Tr: &xdr.OperationResultTr{
+			Type:              xdr.OperationTypePathPayment,
+			PathPaymentResult: &xdr.PathPaymentResult{Code: xdr.PathPaymentResultCodePathPaymentSuccess},
+		},
+	}
+	assert.Equal(t, "op_unknown", OperationResultString(pathPayment), "Path payments aren't supported by txnbuild")
+}
@@ -0,0 +1,383 @@
+package txnbuild
+
+import (
+	"strings"
+
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// AccountFlag represents the bitmask flags used to set and clear account authorization options.
+type AccountFlag uint32
+
+// AuthRequired is a flag that requires the issuing account to give other accounts
+// permission before they can hold the issuing account's credit.
+const AuthRequired = AccountFlag(xdr.AccountFlagsAuthRequiredFlag)
+
+// AuthRevocable is a flag that allows the issuing account to revoke its credit
+// held by other accounts.
+const AuthRevocable = AccountFlag(xdr.AccountFlagsAuthRevocableFlag)
+
+// AuthImmutable is a flag that if set prevents any authorization flags from being
+// set, and prevents the account from ever being merged (deleted).
+const AuthImmutable = AccountFlag(xdr.AccountFlagsAuthImmutableFlag)
+
+// CombineFlags returns the bitmask of all the given flags.
+func CombineFlags(flags ...AccountFlag) xdr.Uint32 {
+	var mask xdr.Uint32
+	for _, flag := range flags {
+		mask = mask | xdr.Uint32(flag)
+	}
+	return mask
+}
+
+// SplitFlags returns the individual flags set in a bitmask, in ascending order.
+func SplitFlags(mask xdr.Uint32) []AccountFlag {
+	var flags []AccountFlag
+	for bit := xdr.Uint32(1); bit != 0 && bit <= mask; bit = bit << 1 {
+		if mask&bit != 0 {
+			flags = append(flags, AccountFlag(bit))
+		}
+	}
+	return flags
+}
+
+// ApplyFlagChanges returns the account flags that result from applying a SetOptions
+// operation's set and clear flags to the current flags. As in stellar-core, the flags to
+// clear are removed before the flags to set are added.
+func ApplyFlagChanges(current xdr.Uint32, set, clear []AccountFlag) xdr.Uint32 {
+	return (current &^ CombineFlags(clear...)) | CombineFlags(set...)
+}
+
+// Threshold is the datatype for MasterWeight, Signer.Weight, and Thresholds. Each is a number
+// between 0-255 inclusive.
+type Threshold uint8
+
+// MaxSignersPerAccount is the maximum number of signers, besides the master key, that an
+// account can have.
+const MaxSignersPerAccount = 20
+
+// Signer represents the Signer in a SetOptions operation. If the signer already exists,
+// it is updated. If the weight is 0, the signer is deleted.
+type Signer struct {
+	Address string
+	Weight  Threshold
+}
+
+// NewHomeDomain is syntactic sugar that makes instantiating SetOptions more convenient.
+func NewHomeDomain(hd string) *string {
+	return &hd
+}
+
+// NewInflationDestination is syntactic sugar that makes instantiating SetOptions more convenient.
+func NewInflationDestination(ai string) *string {
+	return &ai
+}
+
+// NewThreshold is syntactic sugar that makes instantiating SetOptions more convenient.
+func NewThreshold(t Threshold) *Threshold {
+	return &t
+}
+
+// NewSetInflationDestination returns a SetOptions operation that only sets the account's
+// inflation destination. The address is validated before the operation is returned.
+func NewSetInflationDestination(address string) (*SetOptions, error) {
+	_, err := strkey.Decode(strkey.VersionByteAccountID, address)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid inflation destination address")
+	}
+
+	return &SetOptions{InflationDestination: NewInflationDestination(address)}, nil
+}
+
+// NewLockAccountOp returns a SetOptions operation that permanently locks the account, by
+// setting the master key weight to zero and raising all thresholds to the maximum. Once
+// applied, the master key can no longer authorise any transaction for the account, and
+// unless other signers with a combined weight of 255 remain, this can never be reversed.
+func NewLockAccountOp() *SetOptions {
+	return &SetOptions{
+		MasterWeight:    NewThreshold(0),
+		LowThreshold:    NewThreshold(255),
+		MediumThreshold: NewThreshold(255),
+		HighThreshold:   NewThreshold(255),
+	}
+}
+
+// NewSetThresholdsOp returns a SetOptions operation that only sets the account's low, medium
+// and high thresholds.
+func NewSetThresholdsOp(low, medium, high uint8) *SetOptions {
+	return &SetOptions{
+		LowThreshold:    NewThreshold(Threshold(low)),
+		MediumThreshold: NewThreshold(Threshold(medium)),
+		HighThreshold:   NewThreshold(Threshold(high)),
+	}
+}
+
+// NewIssuerSetupOp returns a SetOptions operation that prepares an issuing account, by
+// setting the AuthRequired and AuthRevocable flags and the account's home domain.
+func NewIssuerSetupOp(homeDomain string) (*SetOptions, error) {
+	if homeDomain == "" {
+		return nil, errors.New("HomeDomain must be set")
+	}
+	err := validateHomeDomain(homeDomain)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid home domain")
+	}
+
+	return &SetOptions{
+		SetFlags:   []AccountFlag{AuthRequired, AuthRevocable},
+		HomeDomain: NewHomeDomain(homeDomain),
+	}, nil
+}
+
+// SetOptions represents the Stellar set options operation. See
+// https://www.stellar.org/developers/guides/concepts/list-of-operations.html
+type SetOptions struct {
+	InflationDestination *string
+	SetFlags             []AccountFlag
+	ClearFlags           []AccountFlag
+	MasterWeight         *Threshold
+	LowThreshold         *Threshold
+	MediumThreshold      *Threshold
+	HighThreshold        *Threshold
+	HomeDomain           *string
+	Signer               *Signer
+	SourceAccount        string
+	xdrOp                xdr.SetOptionsOp
+}
+
+// RemoveSigner configures the SetOptions operation to delete the signer with the given
+// address from the account, by setting its weight to zero.
+func (so *SetOptions) RemoveSigner(address string) {
+	so.Signer = &Signer{Address: address, Weight: 0}
+}
+
+// BuildXDR for SetOptions returns a fully configured XDR Operation.
+func (so *SetOptions) BuildXDR() (xdr.Operation, error) {
+	// Start from scratch, so that fields cleared since a previous build aren't kept
+	so.xdrOp = xdr.SetOptionsOp{}
+
+	err := so.handleInflation()
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, withValue("Failed to set inflation destination address", *so.InflationDestination))
+	}
+
+	so.handleClearFlags()
+	so.handleSetFlags()
+	so.handleMasterWeight()
+	so.handleLowThreshold()
+	so.handleMediumThreshold()
+	so.handleHighThreshold()
+	err = so.handleHomeDomain()
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to set home domain")
+	}
+
+	err = so.handleSigner()
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, withValue("Failed to set signer", so.Signer.Address))
+	}
+
+	opType := xdr.OperationTypeSetOptions
+	body, err := xdr.NewOperationBody(opType, so.xdrOp)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to build XDR OperationBody")
+	}
+
+	op := xdr.Operation{Body: body}
+	err = setOpSourceAccount(&op, so.SourceAccount)
+	if err != nil {
+		return xdr.Operation{}, errors.Wrap(err, "Failed to set source account")
+	}
+
+	return op, nil
+}
+
+// changesAuthorization returns true if the SetOptions operation changes the account's flags,
+// signers or thresholds.
+func (so *SetOptions) changesAuthorization() bool {
+	return CombineFlags(so.SetFlags...) != 0 ||
+		CombineFlags(so.ClearFlags...) != 0 ||
+		so.Signer != nil ||
+		so.MasterWeight != nil ||
+		so.LowThreshold != nil ||
+		so.MediumThreshold != nil ||
+		so.HighThreshold != nil
+}
+
+// handleInflation for SetOptions sets the XDR inflation destination.
+// Once set, a new address can be set, but there's no way to ever unset.
+func (so *SetOptions) handleInflation() (err error) {
+	if so.InflationDestination != nil {
+		xdrAccountID := xdr.AccountId{}
+		err = xdrAccountID.SetAddress(*so.InflationDestination)
+		if err != nil {
+			return
+		}
+		so.xdrOp.InflationDest = &xdrAccountID
+	}
+	return
+}
+
+// handleSetFlags for SetOptions sets XDR account flags (represented as a bitmask).
+// See https://www.stellar.org/developers/guides/concepts/accounts.html
+func (so *SetOptions) handleSetFlags() {
+	flags := CombineFlags(so.SetFlags...)
+	// A zero mask would be a no-op, so leave the field unset
+	if flags != 0 {
+		so.xdrOp.SetFlags = &flags
+	}
+}
+
+// handleClearFlags for SetOptions unsets XDR account flags (represented as a bitmask).
+// See https://www.stellar.org/developers/guides/concepts/accounts.html
+func (so *SetOptions) handleClearFlags() {
+	flags := CombineFlags(so.ClearFlags...)
+	// A zero mask would be a no-op, so leave the field unset
+	if flags != 0 {
+		so.xdrOp.ClearFlags = &flags
+	}
+}
+
+// handleMasterWeight for SetOptions sets the XDR weight of the master signing key.
+// See https://www.stellar.org/developers/guides/concepts/multi-sig.html
+func (so *SetOptions) handleMasterWeight() {
+	if so.MasterWeight != nil {
+		xdrWeight := xdr.Uint32(*so.MasterWeight)
+		so.xdrOp.MasterWeight = &xdrWeight
+	}
+}
+
+// handleLowThreshold for SetOptions sets the XDR value of the account's "low" threshold.
+// See https://www.stellar.org/developers/guides/concepts/multi-sig.html
+func (so *SetOptions) handleLowThreshold() {
+	if so.LowThreshold != nil {
+		xdrThreshold := xdr.Uint32(*so.LowThreshold)
+		so.xdrOp.LowThreshold = &xdrThreshold
+	}
+}
+
+// handleMediumThreshold for SetOptions sets the XDR value of the account's "medium" threshold.
+// See https://www.stellar.org/developers/guides/concepts/multi-sig.html
+func (so *SetOptions) handleMediumThreshold() {
+	if so.MediumThreshold != nil {
+		xdrThreshold := xdr.Uint32(*so.MediumThreshold)
+		so.xdrOp.MedThreshold = &xdrThreshold
+	}
+}
+
+// handleHighThreshold for SetOptions sets the XDR value of the account's "high" threshold.
+// See https://www.stellar.org/developers/guides/concepts/multi-sig.html
+func (so *SetOptions) handleHighThreshold() {
+	if so.HighThreshold != nil {
+		xdrThreshold := xdr.Uint32(*so.HighThreshold)
+		so.xdrOp.HighThreshold = &xdrThreshold
+	}
+}
+
+// handleHomeDomain for SetOptions sets the XDR value of the account's home domain.
+// https://www.stellar.org/developers/guides/concepts/federation.html
+func (so *SetOptions) handleHomeDomain() error {
+	if so.HomeDomain != nil {
+		err := validateHomeDomain(*so.HomeDomain)
+		if err != nil {
+			return err
+		}
+		xdrHomeDomain := xdr.String32(*so.HomeDomain)
+		so.xdrOp.HomeDomain = &xdrHomeDomain
+	}
+
+	return nil
+}
+
+// validateHomeDomain returns an error if the home domain can't be set on an account. An
+// empty home domain is valid, and clears the account's home domain. Otherwise it must have
+// the shape of a hostname: dot separated labels of letters, digits and hyphens.
+func validateHomeDomain(homeDomain string) error {
+	if len(homeDomain) > 32 {
+		return errors.New("HomeDomain must be 32 characters or less")
+	}
+	if homeDomain == "" {
+		return nil
+	}
+
+	for _, label := range strings.Split(homeDomain, ".") {
+		if label == "" {
+			return errors.Errorf("HomeDomain %q is not a hostname: empty label", homeDomain)
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return errors.Errorf("HomeDomain %q is not a hostname: label %q starts or ends with a hyphen", homeDomain, label)
+		}
+		for _, c := range label {
+			isValid := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '-'
+			if !isValid {
+				return errors.Errorf("HomeDomain %q is not a hostname: invalid character %q", homeDomain, c)
+			}
+		}
+	}
+
+	return nil
+}
+
+// handleSigner for SetOptions sets the XDR value of a signer for the account.
+// See https://www.stellar.org/developers/guides/concepts/multi-sig.html
+func (so *SetOptions) handleSigner() (err error) {
+	if so.Signer != nil {
+		xdrSigner := xdr.Signer{Weight: xdr.Uint32(so.Signer.Weight)}
+		err = xdrSigner.Key.SetAddress(so.Signer.Address)
+		if err != nil {
+			return
+		}
+
+		so.xdrOp.Signer = &xdrSigner
+	}
+	return nil
+}
+
+// GetSourceAccount returns the source account of the SetOptions operation, if one is set.
+func (so *SetOptions) GetSourceAccount() string {
+	return so.SourceAccount
+}
+
+// FromXDR for SetOptions initialises the operation from an XDR Operation.
+func (so *SetOptions) FromXDR(xdrOp xdr.Operation) error {
+	result, ok := xdrOp.Body.GetSetOptionsOp()
+	if !ok {
+		return errors.New("Operation is not a set options")
+	}
+
+	*so = SetOptions{SourceAccount: opSourceAccountFromXDR(xdrOp)}
+	if result.InflationDest != nil {
+		so.InflationDestination = NewInflationDestination(result.InflationDest.Address())
+	}
+	if result.SetFlags != nil {
+		so.SetFlags = SplitFlags(*result.SetFlags)
+	}
+	if result.ClearFlags != nil {
+		so.ClearFlags = SplitFlags(*result.ClearFlags)
+	}
+	so.MasterWeight = thresholdFromXDR(result.MasterWeight)
+	so.LowThreshold = thresholdFromXDR(result.LowThreshold)
+	so.MediumThreshold = thresholdFromXDR(result.MedThreshold)
+	so.HighThreshold = thresholdFromXDR(result.HighThreshold)
+	if result.HomeDomain != nil {
+		so.HomeDomain = NewHomeDomain(string(*result.HomeDomain))
+	}
+	if result.Signer != nil {
+		so.Signer = &Signer{
+			Address: result.Signer.Key.Address(),
+			Weight:  Threshold(result.Signer.Weight),
+		}
+	}
+
+	return nil
+}
+
+// thresholdFromXDR returns the Threshold for an optional XDR weight or threshold.
+func thresholdFromXDR(value *xdr.Uint32) *Threshold {
+	if value == nil {
+		return nil
+	}
+	return NewThreshold(Threshold(*value))
+}
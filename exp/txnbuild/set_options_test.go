@@ -0,0 +1,338 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetOptions(t *testing.T) {
+	kp1 := newKeypair1()
+	setOptions := SetOptions{
+		InflationDestination: NewInflationDestination(kp1.Address()),
+		SetFlags:             []AccountFlag{AuthRequired, AuthRevocable},
+		ClearFlags:           []AccountFlag{AuthImmutable},
+		MasterWeight:         NewThreshold(10),
+		LowThreshold:         NewThreshold(1),
+		MediumThreshold:      NewThreshold(2),
+		HighThreshold:        NewThreshold(3),
+		HomeDomain:           NewHomeDomain("example.com"),
+		Signer:               &Signer{Address: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H", Weight: 5},
+	}
+
+	xdrOp, err := setOptions.BuildXDR()
+	assert.Nil(t, err)
+
+	opts := xdrOp.Body.MustSetOptionsOp()
+	assert.Equal(t, kp1.Address(), opts.InflationDest.Address())
+	assert.Equal(t, xdr.Uint32(3), *opts.SetFlags)
+	assert.Equal(t, xdr.Uint32(4), *opts.ClearFlags)
+	assert.Equal(t, xdr.Uint32(10), *opts.MasterWeight)
+	assert.Equal(t, xdr.Uint32(1), *opts.LowThreshold)
+	assert.Equal(t, xdr.Uint32(2), *opts.MedThreshold)
+	assert.Equal(t, xdr.Uint32(3), *opts.HighThreshold)
+	assert.Equal(t, xdr.String32("example.com"), *opts.HomeDomain)
+	assert.Equal(t, xdr.Uint32(5), opts.Signer.Weight)
+	assert.Equal(t, "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H", opts.Signer.Key.Address())
+}
+
+func TestSetOptionsUnsetFields(t *testing.T) {
+	setOptions := SetOptions{}
+
+	xdrOp, err := setOptions.BuildXDR()
+	assert.Nil(t, err)
+
+	opts := xdrOp.Body.MustSetOptionsOp()
+	assert.Nil(t, opts.InflationDest)
+	assert.Nil(t, opts.SetFlags)
+	assert.Nil(t, opts.ClearFlags)
+	assert.Nil(t, opts.MasterWeight)
+	assert.Nil(t, opts.HomeDomain)
+	assert.Nil(t, opts.Signer)
+}
+
+func TestSetOptionsRebuildAfterClearing(t *testing.T) {
+	setOptions := SetOptions{
+		SetFlags:     []AccountFlag{AuthRequired},
+		ClearFlags:   []AccountFlag{AuthRevocable},
+		MasterWeight: NewThreshold(10),
+		LowThreshold: NewThreshold(1),
+		HomeDomain:   NewHomeDomain("example.com"),
+		Signer:       &Signer{Address: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H", Weight: 5},
+	}
+	_, err := setOptions.BuildXDR()
+	assert.Nil(t, err)
+
+	setOptions.SetFlags = nil
+	setOptions.ClearFlags = nil
+	setOptions.MasterWeight = nil
+	setOptions.LowThreshold = nil
+	setOptions.HomeDomain = nil
+	setOptions.Signer = nil
+	xdrOp, err := setOptions.BuildXDR()
+	assert.Nil(t, err)
+
+	opts := xdrOp.Body.MustSetOptionsOp()
+	assert.Nil(t, opts.SetFlags)
+	assert.Nil(t, opts.ClearFlags)
+	assert.Nil(t, opts.MasterWeight)
+	assert.Nil(t, opts.LowThreshold)
+	assert.Nil(t, opts.HomeDomain)
+	assert.Nil(t, opts.Signer, "A cleared signer should not be rebuilt")
+}
+
+func TestSetOptionsZeroAndNonZeroFlags(t *testing.T) {
+	setOptions := SetOptions{
+		SetFlags: []AccountFlag{0, AuthRevocable},
+	}
+
+	xdrOp, err := setOptions.BuildXDR()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.Uint32(AuthRevocable), *xdrOp.Body.MustSetOptionsOp().SetFlags)
+}
+
+func TestSetOptionsInvalidSigner(t *testing.T) {
+	setOptions := SetOptions{
+		Signer: &Signer{Address: "GBADADDRESS", Weight: 1},
+	}
+
+	_, err := setOptions.BuildXDR()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Failed to set signer")
+	}
+}
+
+func TestRemoveSigner(t *testing.T) {
+	setOptions := SetOptions{}
+	setOptions.RemoveSigner("GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H")
+
+	xdrOp, err := setOptions.BuildXDR()
+	assert.Nil(t, err)
+
+	signer := xdrOp.Body.MustSetOptionsOp().Signer
+	if assert.NotNil(t, signer, "Zero weight signer should not be dropped") {
+		assert.Equal(t, xdr.Uint32(0), signer.Weight)
+		assert.Equal(t, "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H", signer.Key.Address())
+	}
+}
+
+func TestNewSetInflationDestination(t *testing.T) {
+	kp1 := newKeypair1()
+	setOptions, err := NewSetInflationDestination(kp1.Address())
+	assert.Nil(t, err)
+
+	xdrOp, err := setOptions.BuildXDR()
+	assert.Nil(t, err)
+
+	opts := xdrOp.Body.MustSetOptionsOp()
+	assert.Equal(t, kp1.Address(), opts.InflationDest.Address())
+	assert.Nil(t, opts.SetFlags)
+	assert.Nil(t, opts.HomeDomain)
+	assert.Nil(t, opts.Signer)
+}
+
+func TestNewSetInflationDestinationInvalidAddress(t *testing.T) {
+	_, err := NewSetInflationDestination("GBADADDRESS")
+	assert.Error(t, err)
+
+	_, err = NewSetInflationDestination(newKeypair1().Seed())
+	assert.Error(t, err)
+}
+
+func TestSetOptionsAllZeroFlags(t *testing.T) {
+	setOptions := SetOptions{
+		SetFlags:   []AccountFlag{0, 0},
+		ClearFlags: []AccountFlag{0},
+	}
+
+	xdrOp, err := setOptions.BuildXDR()
+	assert.Nil(t, err)
+
+	opts := xdrOp.Body.MustSetOptionsOp()
+	assert.Nil(t, opts.SetFlags, "Zero mask should leave SetFlags unset")
+	assert.Nil(t, opts.ClearFlags, "Zero mask should leave ClearFlags unset")
+}
+
+func TestRejectZeroFlags(t *testing.T) {
+	newTx := func(strict bool, ops ...Operation) *Transaction {
+		tx := newTestTx(ops...)
+		tx.RejectZeroFlags = strict
+		return tx
+	}
+
+	permissive := newTx(false, &SetOptions{SetFlags: []AccountFlag{0, AuthRevocable}})
+	assert.Nil(t, permissive.Build())
+
+	strictSet := newTx(true, &Inflation{}, &SetOptions{SetFlags: []AccountFlag{0, AuthRevocable}})
+	assert.EqualError(t, strictSet.Build(), "Invalid operations: operation 1 has a zero flag in SetFlags, which is not a real flag")
+
+	strictClear := newTx(true, &SetOptions{ClearFlags: []AccountFlag{AuthRequired, 0}})
+	assert.EqualError(t, strictClear.Build(), "Invalid operations: operation 0 has a zero flag in ClearFlags, which is not a real flag")
+
+	strictValid := newTx(true, &SetOptions{SetFlags: []AccountFlag{AuthRevocable}, ClearFlags: []AccountFlag{AuthRequired}})
+	assert.Nil(t, strictValid.Build())
+}
+
+func TestCombineAndSplitFlags(t *testing.T) {
+	mask := CombineFlags(AuthRequired, AuthRevocable, AuthImmutable)
+	assert.Equal(t, xdr.Uint32(7), mask)
+	assert.Equal(t, []AccountFlag{AuthRequired, AuthRevocable, AuthImmutable}, SplitFlags(mask))
+
+	assert.Equal(t, []AccountFlag{AuthRevocable}, SplitFlags(CombineFlags(AuthRevocable, AuthRevocable)))
+	assert.Nil(t, SplitFlags(0))
+}
+
+func TestNewLockAccountOp(t *testing.T) {
+	xdrOp, err := NewLockAccountOp().BuildXDR()
+	assert.Nil(t, err)
+
+	opts := xdrOp.Body.MustSetOptionsOp()
+	assert.Equal(t, xdr.Uint32(0), *opts.MasterWeight)
+	assert.Equal(t, xdr.Uint32(255), *opts.LowThreshold)
+	assert.Equal(t, xdr.Uint32(255), *opts.MedThreshold)
+	assert.Equal(t, xdr.Uint32(255), *opts.HighThreshold)
+	assert.Nil(t, opts.Signer)
+}
+
+func TestNewSetThresholdsOp(t *testing.T) {
+	xdrOp, err := NewSetThresholdsOp(1, 2, 3).BuildXDR()
+	assert.Nil(t, err)
+
+	opts := xdrOp.Body.MustSetOptionsOp()
+	assert.Equal(t, xdr.Uint32(1), *opts.LowThreshold)
+	assert.Equal(t, xdr.Uint32(2), *opts.MedThreshold)
+	assert.Equal(t, xdr.Uint32(3), *opts.HighThreshold)
+	assert.Nil(t, opts.MasterWeight)
+	assert.Nil(t, opts.Signer)
+	assert.Nil(t, opts.SetFlags)
+}
+
+func TestApplyFlagChanges(t *testing.T) {
+	current := CombineFlags(AuthRequired, AuthImmutable)
+
+	result := ApplyFlagChanges(current, []AccountFlag{AuthRevocable}, []AccountFlag{AuthImmutable})
+	assert.Equal(t, CombineFlags(AuthRequired, AuthRevocable), result)
+
+	result = ApplyFlagChanges(current, []AccountFlag{AuthImmutable}, []AccountFlag{AuthImmutable})
+	assert.Equal(t, current, result, "Set flags should be applied after clear flags")
+
+	assert.Equal(t, current, ApplyFlagChanges(current, nil, nil))
+}
+
+func TestCheckAuthImmutableConflicts(t *testing.T) {
+	kp1 := newKeypair1()
+	makeImmutable := &SetOptions{SetFlags: []AccountFlag{AuthImmutable}}
+	addSigner := &SetOptions{Signer: &Signer{Address: kp1.Address(), Weight: 1}}
+
+	conflicting := newTestTx(makeImmutable, addSigner)
+	assert.Error(t, conflicting.CheckAuthImmutableConflicts())
+
+	merge := newTestTx(makeImmutable, &AccountMerge{Destination: kp1.Address()})
+	assert.Error(t, merge.CheckAuthImmutableConflicts())
+
+	safe := newTestTx(addSigner, makeImmutable, &SetOptions{HomeDomain: NewHomeDomain("example.com")})
+	assert.Nil(t, safe.CheckAuthImmutableConflicts())
+
+	otherAccount := newTestTx(makeImmutable, &SetOptions{Signer: addSigner.Signer, SourceAccount: kp1.Address()})
+	assert.Nil(t, otherAccount.CheckAuthImmutableConflicts())
+}
+
+func TestRejectSelfInflation(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+	newTx := func(strict bool, ops ...Operation) *Transaction {
+		tx := newTestTx(ops...)
+		tx.RejectSelfInflation = strict
+		return tx
+	}
+
+	permissive := newTx(false, &SetOptions{InflationDestination: NewInflationDestination(kp0.Address())})
+	assert.Nil(t, permissive.Build())
+
+	strict := newTx(true, &SetOptions{InflationDestination: NewInflationDestination(kp0.Address())})
+	assert.Error(t, strict.Build())
+
+	opSource := newTx(true, &SetOptions{InflationDestination: NewInflationDestination(kp1.Address()), SourceAccount: kp1.Address()})
+	assert.Error(t, opSource.Build())
+
+	other := newTx(true, &SetOptions{InflationDestination: NewInflationDestination(kp1.Address())})
+	assert.Nil(t, other.Build())
+}
+
+func TestModifiesAuthorization(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+
+	payment := newTestTx(&Payment{Destination: kp1.Address(), Amount: "10"})
+	assert.False(t, payment.ModifiesAuthorization())
+
+	homeDomain := newTestTx(&SetOptions{HomeDomain: NewHomeDomain("example.com")})
+	assert.False(t, homeDomain.ModifiesAuthorization())
+
+	signer := newTestTx(&Payment{Destination: kp1.Address(), Amount: "10"}, &SetOptions{Signer: &Signer{Address: kp1.Address(), Weight: 1}})
+	assert.True(t, signer.ModifiesAuthorization())
+
+	flags := newTestTx(&SetOptions{ClearFlags: []AccountFlag{AuthRevocable}})
+	assert.True(t, flags.ModifiesAuthorization())
+
+	allowTrust := newTestTx(&AllowTrust{Trustor: kp1.Address(), Type: Asset{Code: "USD", Issuer: kp0.Address()}, Authorize: true})
+	assert.True(t, allowTrust.ModifiesAuthorization())
+}
+
+func TestNewIssuerSetupOp(t *testing.T) {
+	setOptions, err := NewIssuerSetupOp("example.com")
+	assert.Nil(t, err)
+
+	xdrOp, err := setOptions.BuildXDR()
+	assert.Nil(t, err)
+	opts := xdrOp.Body.MustSetOptionsOp()
+	assert.Equal(t, CombineFlags(AuthRequired, AuthRevocable), *opts.SetFlags)
+	assert.Nil(t, opts.ClearFlags)
+	assert.Equal(t, xdr.String32("example.com"), *opts.HomeDomain)
+
+	_, err = NewIssuerSetupOp("")
+	assert.Error(t, err)
+
+	_, err = NewIssuerSetupOp("a-home-domain-that-is-far-too-long.example.com")
+	assert.Error(t, err)
+}
+
+func TestSetOptionsHomeDomainShape(t *testing.T) {
+	for _, homeDomain := range []string{"example.com", "sub.example.com", "my-bank.example", ""} {
+		setOptions := SetOptions{HomeDomain: NewHomeDomain(homeDomain)}
+		_, err := setOptions.BuildXDR()
+		assert.Nil(t, err, homeDomain)
+	}
+
+	for _, homeDomain := range []string{".example.com", "example.com.", "example..com", "-example.com", "exa mple.com"} {
+		setOptions := SetOptions{HomeDomain: NewHomeDomain(homeDomain)}
+		_, err := setOptions.BuildXDR()
+		assert.Error(t, err, homeDomain)
+	}
+}
+
+func TestRejectExcessSigners(t *testing.T) {
+	newTx := func(numSigners int) *Transaction {
+		var ops []Operation
+		for i := 0; i < numSigners; i++ {
+			kp, err := keypair.Random()
+			assert.Nil(t, err)
+			ops = append(ops, &SetOptions{Signer: &Signer{Address: kp.Address(), Weight: 1}})
+		}
+		tx := newTestTx(ops...)
+		tx.RejectExcessSigners = true
+		return tx
+	}
+
+	maxSigners := newTx(MaxSignersPerAccount)
+	assert.Nil(t, maxSigners.Build())
+
+	tooMany := newTx(MaxSignersPerAccount + 1)
+	assert.Error(t, tooMany.Build())
+
+	tooMany.Reset()
+	tooMany.RejectExcessSigners = false
+	assert.Nil(t, tooMany.Build(), "Signer count is only checked when enabled")
+}
@@ -0,0 +1,147 @@
+package txnbuild
+
+import (
+	"sort"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/support/errors"
+)
+
+// ThresholdCategory identifies which of an account's thresholds an operation must meet. See
+// https://www.stellar.org/developers/guides/concepts/multi-sig.html#thresholds
+type ThresholdCategory int
+
+const (
+	// ThresholdLow is the category of operations requiring the account's low threshold.
+	ThresholdLow ThresholdCategory = iota
+	// ThresholdMedium is the category of operations requiring the account's medium threshold.
+	ThresholdMedium
+	// ThresholdHigh is the category of operations requiring the account's high threshold.
+	ThresholdHigh
+)
+
+// Thresholds represents the low, medium and high thresholds configured for an account.
+type Thresholds struct {
+	Low    uint8
+	Medium uint8
+	High   uint8
+}
+
+// For returns the threshold for the given category.
+func (t Thresholds) For(category ThresholdCategory) uint8 {
+	switch category {
+	case ThresholdLow:
+		return t.Low
+	case ThresholdMedium:
+		return t.Medium
+	default:
+		return t.High
+	}
+}
+
+// operationThresholdCategory returns the threshold category required by an operation.
+// SetOptions only requires the high threshold when it changes the master weight, thresholds
+// or signers; otherwise it requires the medium threshold.
+func operationThresholdCategory(op Operation) ThresholdCategory {
+	switch o := op.(type) {
+	case *BumpSequence, *Inflation, *AllowTrust:
+		return ThresholdLow
+	case *AccountMerge:
+		return ThresholdHigh
+	case *SetOptions:
+		if o.MasterWeight != nil || o.LowThreshold != nil || o.MediumThreshold != nil ||
+			o.HighThreshold != nil || o.Signer != nil {
+			return ThresholdHigh
+		}
+		return ThresholdMedium
+	default:
+		return ThresholdMedium
+	}
+}
+
+// requiredThreshold returns the highest threshold the Transaction's source account must meet,
+// across the Transaction itself and the operations that do not set their own source account.
+func requiredThreshold(tx *Transaction, thresholds Thresholds) uint8 {
+	required := thresholds.Low
+	for _, op := range tx.Operations {
+		if source := op.GetSourceAccount(); source != "" && source != tx.SourceAccount.ID {
+			continue
+		}
+
+		threshold := thresholds.For(operationThresholdCategory(op))
+		if threshold > required {
+			required = threshold
+		}
+	}
+
+	return required
+}
+
+// IsFullySigned returns true if the signatures on the Transaction envelope carry enough
+// weight to meet the highest threshold required by the Transaction. signers maps each of the
+// source account's signer addresses (including the master key) to its weight.
+func (tx *Transaction) IsFullySigned(signers map[string]uint32, thresholds Thresholds) (bool, error) {
+	if tx.xdrEnvelope == nil {
+		return false, nil
+	}
+
+	hash, err := tx.Hash()
+	if err != nil {
+		return false, errors.Wrap(err, "Failed to hash transaction")
+	}
+
+	var weight uint32
+	for address, signerWeight := range signers {
+		kp, err := keypair.Parse(address)
+		if err != nil {
+			return false, errors.Wrapf(err, "Invalid signer address %s", address)
+		}
+
+		for _, sig := range tx.xdrEnvelope.Signatures {
+			if verifyDecoratedSignature(hash, sig, []keypair.KP{kp}) {
+				weight += signerWeight
+				break
+			}
+		}
+	}
+
+	required := uint32(requiredThreshold(tx, thresholds))
+	return weight > 0 && weight >= required, nil
+}
+
+// MinimalSigners returns the smallest set of the source account's signers whose combined
+// weight meets the highest threshold required by the Transaction. signers maps each signer
+// address (including the master key) to its weight. Signers are chosen greedily, heaviest
+// first.
+func MinimalSigners(tx *Transaction, signers map[string]uint32, thresholds Thresholds) ([]string, error) {
+	required := uint32(requiredThreshold(tx, thresholds))
+	// A signature is always needed, even if the threshold is zero
+	if required == 0 {
+		required = 1
+	}
+
+	var addresses []string
+	for address, weight := range signers {
+		if weight > 0 {
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		if signers[addresses[i]] != signers[addresses[j]] {
+			return signers[addresses[i]] > signers[addresses[j]]
+		}
+		return addresses[i] < addresses[j]
+	})
+
+	var chosen []string
+	var total uint32
+	for _, address := range addresses {
+		chosen = append(chosen, address)
+		total += signers[address]
+		if total >= required {
+			return chosen, nil
+		}
+	}
+
+	return nil, errors.Errorf("signers have a combined weight of %d, below the required threshold of %d", total, required)
+}
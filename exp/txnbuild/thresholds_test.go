@@ -0,0 +1,108 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThresholdsFor(t *testing.T) {
+	thresholds := Thresholds{Low: 1, Medium: 5, High: 10}
+	assert.Equal(t, uint8(1), thresholds.For(ThresholdLow))
+	assert.Equal(t, uint8(5), thresholds.For(ThresholdMedium))
+	assert.Equal(t, uint8(10), thresholds.For(ThresholdHigh))
+}
+
+func TestMinimalSigners(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+	other := "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"
+	thresholds := Thresholds{Low: 1, Medium: 5, High: 10}
+
+	signers := map[string]uint32{kp0.Address(): 10, kp1.Address(): 5, other: 5}
+	chosen, err := MinimalSigners(newTestTx(&AccountMerge{Destination: other}), signers, thresholds)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{kp0.Address()}, chosen, "A single high weight signer should suffice")
+
+	signers = map[string]uint32{kp0.Address(): 0, kp1.Address(): 6, other: 4}
+	chosen, err = MinimalSigners(newTestTx(&AccountMerge{Destination: other}), signers, thresholds)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{kp1.Address(), other}, chosen)
+
+	chosen, err = MinimalSigners(newTestTx(&Inflation{}), signers, thresholds)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{kp1.Address()}, chosen)
+
+	// Operations for another source account don't count towards the transaction source
+	chosen, err = MinimalSigners(newTestTx(&Inflation{}, &AccountMerge{Destination: other, SourceAccount: other}), signers, thresholds)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{kp1.Address()}, chosen)
+
+	signers = map[string]uint32{kp1.Address(): 3, other: 4}
+	_, err = MinimalSigners(newTestTx(&AccountMerge{Destination: other}), signers, thresholds)
+	assert.Error(t, err)
+
+	// AllowTrust only needs the low threshold
+	signers = map[string]uint32{other: 4}
+	allowTrust := &AllowTrust{Trustor: kp1.Address(), Type: Asset{Code: "USD", Issuer: kp0.Address()}, Authorize: true}
+	chosen, err = MinimalSigners(newTestTx(allowTrust), signers, thresholds)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{other}, chosen)
+	_, err = MinimalSigners(newTestTx(&Payment{Destination: other, Amount: "10"}), signers, thresholds)
+	assert.Error(t, err)
+
+	// SetOptions only needs the high threshold when it changes weights, thresholds or signers
+	signers = map[string]uint32{kp1.Address(): 6, other: 4}
+	homeDomain := "example.com"
+	chosen, err = MinimalSigners(newTestTx(&SetOptions{HomeDomain: &homeDomain}), signers, thresholds)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{kp1.Address()}, chosen)
+	masterWeight := Threshold(1)
+	chosen, err = MinimalSigners(newTestTx(&SetOptions{MasterWeight: &masterWeight}), signers, thresholds)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{kp1.Address(), other}, chosen)
+}
+
+func TestIsFullySigned(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+	signers := map[string]uint32{kp0.Address(): 5, kp1.Address(): 5}
+	thresholds := Thresholds{Low: 1, Medium: 5, High: 10}
+	newTx := func(ops ...Operation) *Transaction {
+		tx := newTestTx(ops...)
+		err := tx.Build()
+		assert.Nil(t, err)
+		return tx
+	}
+
+	unsigned := newTx(&Inflation{})
+	signed, err := unsigned.IsFullySigned(signers, thresholds)
+	assert.Nil(t, err)
+	assert.False(t, signed)
+
+	medium := newTx(&Payment{Destination: kp1.Address(), Amount: "10"})
+	err = medium.Sign(kp0)
+	assert.Nil(t, err)
+	signed, err = medium.IsFullySigned(signers, thresholds)
+	assert.Nil(t, err)
+	assert.True(t, signed)
+
+	high := newTx(&Payment{Destination: kp1.Address(), Amount: "10"}, &AccountMerge{Destination: kp1.Address()})
+	err = high.Sign(kp0)
+	assert.Nil(t, err)
+	signed, err = high.IsFullySigned(signers, thresholds)
+	assert.Nil(t, err)
+	assert.False(t, signed, "One signer doesn't meet the high threshold")
+
+	err = high.Sign(kp0)
+	assert.Nil(t, err)
+	signed, err = high.IsFullySigned(signers, thresholds)
+	assert.Nil(t, err)
+	assert.False(t, signed, "Repeated signatures don't add weight")
+
+	err = high.Sign(kp1)
+	assert.Nil(t, err)
+	signed, err = high.IsFullySigned(signers, thresholds)
+	assert.Nil(t, err)
+	assert.True(t, signed)
+}
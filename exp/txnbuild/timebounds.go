@@ -0,0 +1,57 @@
+package txnbuild
+
+import (
+	"time"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// Timebounds represents the time window during which a Transaction is valid, as UNIX
+// timestamps in seconds. A MaxTime of 0 means there is no upper bound. See
+// https://www.stellar.org/developers/guides/concepts/transactions.html#time-bounds
+type Timebounds struct {
+	MinTime int64
+	MaxTime int64
+}
+
+// NewTimebounds is a constructor for Timebounds.
+func NewTimebounds(minTime, maxTime int64) Timebounds {
+	return Timebounds{MinTime: minTime, MaxTime: maxTime}
+}
+
+// NewTimeout returns Timebounds that are valid from now until timeout seconds from now.
+func NewTimeout(timeout int64) Timebounds {
+	return Timebounds{MaxTime: time.Now().UTC().Unix() + timeout}
+}
+
+// IsZero returns true if no time bounds have been set.
+func (tb Timebounds) IsZero() bool {
+	return tb.MinTime == 0 && tb.MaxTime == 0
+}
+
+// Validate for Timebounds checks that the time bounds are well formed.
+func (tb Timebounds) Validate() error {
+	if tb.MinTime < 0 || tb.MaxTime < 0 {
+		return errors.New("Time bounds can't be negative")
+	}
+	if tb.MaxTime != 0 && tb.MaxTime < tb.MinTime {
+		return errors.New("Max time can't be earlier than min time")
+	}
+
+	return nil
+}
+
+// validateNotExpired returns an error if the maximum time has already passed.
+func (tb Timebounds) validateNotExpired(now time.Time) error {
+	if tb.MaxTime != 0 && tb.MaxTime < now.Unix() {
+		return errors.Errorf("Max time %d is in the past", tb.MaxTime)
+	}
+
+	return nil
+}
+
+// ToXDR for Timebounds returns an XDR object representation of the Timebounds.
+func (tb Timebounds) ToXDR() xdr.TimeBounds {
+	return xdr.TimeBounds{MinTime: xdr.Uint64(tb.MinTime), MaxTime: xdr.Uint64(tb.MaxTime)}
+}
@@ -0,0 +1,38 @@
+package txnbuild
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrictTimebounds(t *testing.T) {
+	newTx := func(tb Timebounds) *Transaction {
+		tx := newTestTx(&Inflation{})
+		tx.Timebounds = tb
+		tx.StrictTimebounds = true
+		return tx
+	}
+
+	past := time.Now().Add(-time.Hour).Unix()
+	expired := newTx(NewTimebounds(0, past))
+	err := expired.Build()
+	assert.Error(t, err)
+
+	expired.StrictTimebounds = false
+	err = expired.Build()
+	assert.Nil(t, err, "Expired time bounds are only rejected in strict mode")
+
+	future := time.Now().Add(time.Hour).Unix()
+	valid := newTx(NewTimebounds(0, future))
+	err = valid.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.Uint64(future), valid.xdrTransaction.TimeBounds.MaxTime)
+
+	unbounded := newTx(Timebounds{})
+	err = unbounded.Build()
+	assert.Nil(t, err)
+	assert.Nil(t, unbounded.xdrTransaction.TimeBounds)
+}
@@ -4,13 +4,28 @@ import (
 	"bytes"
 	"encoding/base64"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/hash"
 	"github.com/stellar/go/keypair"
 	"github.com/stellar/go/network"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/xdr"
+
+	"golang.org/x/crypto/ed25519"
 )
 
+// MaxOperationsPerTransaction is the maximum number of operations allowed in a Transaction.
+const MaxOperationsPerTransaction = 100
+
+// defaultBaseFee is the base fee, in stroops per operation, used when neither a BaseFee nor a
+// FeeSource is set.
+const defaultBaseFee uint64 = 100
+
 // TODO: Replace use of Horizon Account with simpler Account object here
 type Account struct {
 	ID             string
@@ -23,13 +38,104 @@ type Transaction struct {
 	Operations     []Operation
 	xdrTransaction xdr.Transaction
 	BaseFee        uint64 // TODO: Why is this a uint 64? Can it be a plain int?
+	FeeSource      FeeSource
 	xdrEnvelope    *xdr.TransactionEnvelope
-	Network        string
+	Network        Network
+	Memo           Memo
+	Timebounds     Timebounds
+	// SequenceNumber, when non-zero, is used verbatim as the Transaction's sequence number, for
+	// example when building offline for air-gapped signing. It takes precedence over
+	// SourceAccount.SequenceNumber, the account's current sequence number, which Build
+	// otherwise increments.
+	SequenceNumber int64
+	// StrictTimebounds makes Build reject a Transaction whose max time has already passed.
+	StrictTimebounds bool
+	// RejectDuplicateDataNames makes Build reject a Transaction containing more than one
+	// ManageData operation for the same name.
+	RejectDuplicateDataNames bool
+	// RejectSelfInflation makes Build reject a SetOptions operation that sets the inflation
+	// destination to the operation's own source account.
+	RejectSelfInflation bool
+	// RejectExcessSigners makes Build reject a Transaction that adds more than
+	// MaxSignersPerAccount signers to a single account.
+	RejectExcessSigners bool
+	// RejectZeroFlags makes Build reject a SetOptions operation whose SetFlags or ClearFlags
+	// contain AccountFlag(0), which is not a real flag. By default zero flags are ignored.
+	RejectZeroFlags bool
+}
+
+// validate runs the optional checks enabled on the Transaction, returning the first error
+// found.
+func (tx *Transaction) validate() error {
+	checks := []struct {
+		enabled bool
+		context string
+		check   func() error
+	}{
+		{tx.StrictTimebounds, "Invalid time bounds", tx.checkNotExpired},
+		{tx.RejectDuplicateDataNames, "Invalid operations", tx.checkDuplicateDataNames},
+		{tx.RejectSelfInflation, "Invalid operations", tx.checkSelfInflation},
+		{tx.RejectExcessSigners, "Invalid operations", tx.checkSignerCount},
+		{tx.RejectZeroFlags, "Invalid operations", tx.checkZeroFlags},
+	}
+
+	for _, c := range checks {
+		if !c.enabled {
+			continue
+		}
+		err := c.check()
+		if err != nil {
+			return errors.Wrap(err, c.context)
+		}
+	}
+
+	return nil
 }
 
 // Hash provides a signable object representing the Transaction on the specified network.
 func (tx *Transaction) Hash() ([32]byte, error) {
-	return network.HashTransaction(&tx.xdrTransaction, tx.Network)
+	return network.HashTransaction(&tx.xdrTransaction, string(tx.Network))
+}
+
+// HashFromBase64 returns the network specific hash of the transaction in a base 64 XDR
+// transaction envelope. The envelope types in this version of the XDR have no fee-bump
+// wrapper, so the hash is always that of the enclosed transaction.
+func HashFromBase64(txeB64 string, txNetwork Network) ([32]byte, error) {
+	var envelope xdr.TransactionEnvelope
+	err := xdr.SafeUnmarshalBase64(txeB64, &envelope)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "Failed to unmarshal XDR")
+	}
+
+	return network.HashTransaction(&envelope.Tx, string(txNetwork))
+}
+
+// ToXDR returns a copy of the built XDR transaction. Changes to the copy don't affect the
+// Transaction.
+func (tx *Transaction) ToXDR() (xdr.Transaction, error) {
+	if !tx.built() {
+		return xdr.Transaction{}, errors.New("Transaction has not been built")
+	}
+
+	// Round trip through the binary encoding to copy everything behind pointers and slices
+	var txBytes bytes.Buffer
+	_, err := xdr.Marshal(&txBytes, tx.xdrTransaction)
+	if err != nil {
+		return xdr.Transaction{}, errors.Wrap(err, "Failed to marshal XDR")
+	}
+
+	var xdrTransaction xdr.Transaction
+	err = xdr.SafeUnmarshal(txBytes.Bytes(), &xdrTransaction)
+	if err != nil {
+		return xdr.Transaction{}, errors.Wrap(err, "Failed to unmarshal XDR")
+	}
+
+	return xdrTransaction, nil
+}
+
+// built returns true if the Transaction has been built.
+func (tx *Transaction) built() bool {
+	return tx.xdrTransaction.SourceAccount.Ed25519 != nil
 }
 
 // MarshalBinary returns the binary XDR representation of the Transaction.
@@ -43,6 +149,189 @@ func (tx *Transaction) MarshalBinary() ([]byte, error) {
 	return txBytes.Bytes(), nil
 }
 
+// UnmarshalBinary reconstructs the Transaction from the binary XDR representation of a
+// transaction envelope, as produced by MarshalBinary. Everything but the Network is replaced
+// by what is decoded from the envelope, as in TransactionFromXDR.
+func (tx *Transaction) UnmarshalBinary(data []byte) error {
+	var envelope xdr.TransactionEnvelope
+	err := xdr.SafeUnmarshal(data, &envelope)
+	if err != nil {
+		return errors.Wrap(err, "Failed to unmarshal XDR")
+	}
+
+	return tx.adoptEnvelope(envelope)
+}
+
+// TransactionFromEnvelope returns a Transaction wrapping an existing XDR transaction
+// envelope, such as one produced by another SDK. Its Operations, Memo, BaseFee and
+// Timebounds are decoded from the envelope. Existing signatures are kept, and further
+// signatures may be added with Sign.
+func TransactionFromEnvelope(envelope xdr.TransactionEnvelope, txNetwork Network) (*Transaction, error) {
+	if txNetwork == "" {
+		return nil, errors.New("Network passphrase must be set")
+	}
+
+	tx := &Transaction{Network: txNetwork}
+	err := tx.adoptEnvelope(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// TransactionFromXDR returns a Transaction decoded from a base 64 XDR transaction envelope.
+// Its Operations are reconstructed from the XDR, including any operation-level source
+// accounts. The XDR transaction is retained as is, so the Transaction can be signed
+// directly; call Reset before building it again.
+func TransactionFromXDR(txeB64 string, txNetwork Network) (*Transaction, error) {
+	txBytes, err := base64.StdEncoding.DecodeString(txeB64)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to decode base 64 envelope")
+	}
+
+	var envelope xdr.TransactionEnvelope
+	err = xdr.SafeUnmarshal(txBytes, &envelope)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to unmarshal XDR envelope")
+	}
+
+	return TransactionFromEnvelope(envelope, txNetwork)
+}
+
+// MergeTransactions returns a new, unbuilt Transaction containing the operations of all the
+// given transactions, in order. The transactions must share the same source account, network,
+// memo and time bounds. The fee configuration is taken from the first transaction. Signatures are not
+// carried over, so the merged Transaction must be built and signed again.
+func MergeTransactions(txs ...*Transaction) (*Transaction, error) {
+	if len(txs) == 0 {
+		return nil, errors.New("No transactions to merge")
+	}
+
+	first := txs[0]
+	merged := &Transaction{
+		SourceAccount:  first.SourceAccount,
+		SequenceNumber: first.SequenceNumber,
+		BaseFee:        first.BaseFee,
+		FeeSource:      first.FeeSource,
+		Network:        first.Network,
+		Memo:           first.Memo,
+		Timebounds:     first.Timebounds,
+	}
+	for i, tx := range txs {
+		if tx.SourceAccount != first.SourceAccount {
+			return nil, errors.Errorf("transaction %d has source account %s (sequence %d), expected %s (sequence %d)",
+				i, tx.SourceAccount.ID, tx.SourceAccount.SequenceNumber, first.SourceAccount.ID, first.SourceAccount.SequenceNumber)
+		}
+		if tx.SequenceNumber != first.SequenceNumber {
+			return nil, errors.Errorf("transaction %d has sequence number %d, expected %d", i, tx.SequenceNumber, first.SequenceNumber)
+		}
+		if tx.Network != first.Network {
+			return nil, errors.Errorf("transaction %d is for network %q, expected %q", i, tx.Network, first.Network)
+		}
+		sameMemo, err := memosEqual(tx.Memo, first.Memo)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to compare the memo of transaction %d", i)
+		}
+		if !sameMemo {
+			return nil, errors.Errorf("transaction %d has a different memo to transaction 0", i)
+		}
+		if tx.Timebounds != first.Timebounds {
+			return nil, errors.Errorf("transaction %d has time bounds %d to %d, expected %d to %d", i,
+				tx.Timebounds.MinTime, tx.Timebounds.MaxTime, first.Timebounds.MinTime, first.Timebounds.MaxTime)
+		}
+		merged.Operations = append(merged.Operations, tx.Operations...)
+	}
+
+	if len(merged.Operations) > MaxOperationsPerTransaction {
+		return nil, errors.Errorf("merged transaction has %d operations, more than the maximum of %d",
+			len(merged.Operations), MaxOperationsPerTransaction)
+	}
+
+	return merged, nil
+}
+
+// ConflictingSequence returns true if the two transactions share a source account and
+// sequence number, so that at most one of them can be applied.
+func ConflictingSequence(a, b *Transaction) bool {
+	return a.SourceAccount.ID == b.SourceAccount.ID && a.sequenceNumber() == b.sequenceNumber()
+}
+
+// sequenceNumber returns the sequence number the Transaction is built with.
+func (tx *Transaction) sequenceNumber() xdr.SequenceNumber {
+	if tx.SequenceNumber != 0 {
+		return xdr.SequenceNumber(tx.SequenceNumber)
+	}
+	return tx.SourceAccount.SequenceNumber + 1
+}
+
+// adoptEnvelope replaces the Transaction's XDR state, source account, operations, memo, base
+// fee and time bounds with those decoded from the envelope. Only the Network is kept. The
+// Transaction is unchanged if an operation can't be decoded.
+func (tx *Transaction) adoptEnvelope(envelope xdr.TransactionEnvelope) error {
+	var operations []Operation
+	for _, xdrOp := range envelope.Tx.Operations {
+		op, err := operationFromXDR(xdrOp)
+		if err != nil {
+			return err
+		}
+		operations = append(operations, op)
+	}
+
+	*tx = Transaction{Network: tx.Network, Operations: operations}
+	tx.xdrEnvelope = &envelope
+	tx.xdrTransaction = envelope.Tx
+	tx.SourceAccount = Account{
+		ID:             envelope.Tx.SourceAccount.Address(),
+		SequenceNumber: envelope.Tx.SeqNum - 1,
+	}
+	tx.Memo = memoFromXDR(envelope.Tx.Memo)
+	if numOps := len(envelope.Tx.Operations); numOps > 0 {
+		tx.BaseFee = uint64(envelope.Tx.Fee) / uint64(numOps)
+	}
+	if envelope.Tx.TimeBounds != nil {
+		tx.Timebounds = NewTimebounds(int64(envelope.Tx.TimeBounds.MinTime), int64(envelope.Tx.TimeBounds.MaxTime))
+	}
+
+	return nil
+}
+
+// SizeBytes returns the length in bytes of the binary XDR envelope for the Transaction.
+// The Transaction must have been built first. Signatures are only included in the size
+// once the Transaction has been signed.
+func (tx *Transaction) SizeBytes() (int, error) {
+	var txBytes bytes.Buffer
+	size, err := xdr.Marshal(&txBytes, tx.envelope())
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to marshal XDR")
+	}
+
+	return size, nil
+}
+
+// EnvelopeHash returns the SHA-256 hash of the binary XDR envelope for the Transaction,
+// including its signatures. Unlike Hash, which is what gets signed, it changes whenever a
+// signature is added, so it identifies the exact envelope, for example when deduplicating
+// submissions.
+func (tx *Transaction) EnvelopeHash() ([32]byte, error) {
+	var txBytes bytes.Buffer
+	_, err := xdr.Marshal(&txBytes, tx.envelope())
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "Failed to marshal XDR")
+	}
+
+	return hash.Hash(txBytes.Bytes()), nil
+}
+
+// envelope returns the Transaction envelope, or an unsigned envelope if the Transaction has
+// not been signed.
+func (tx *Transaction) envelope() *xdr.TransactionEnvelope {
+	if tx.xdrEnvelope == nil {
+		return &xdr.TransactionEnvelope{Tx: tx.xdrTransaction}
+	}
+	return tx.xdrEnvelope
+}
+
 // Base64 returns the base 64 XDR representation of the Transaction.
 func (tx *Transaction) Base64() (string, error) {
 	bs, err := tx.MarshalBinary()
@@ -53,29 +342,124 @@ func (tx *Transaction) Base64() (string, error) {
 	return base64.StdEncoding.EncodeToString(bs), nil
 }
 
+// UnsignedBase64 builds the Transaction, if it hasn't been built yet, and returns the base 64 XDR representation of an
+// envelope with no signatures. This supports offline workflows, where the envelope is signed
+// elsewhere, for example by a cold storage key.
+func (tx *Transaction) UnsignedBase64() (string, error) {
+	if !tx.built() {
+		err := tx.Build()
+		if err != nil {
+			return "", errors.Wrap(err, "Failed to build transaction")
+		}
+	}
+
+	envelope := xdr.TransactionEnvelope{Tx: tx.xdrTransaction}
+	txeBase64, err := xdr.MarshalBase64(envelope)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to marshal XDR")
+	}
+
+	return txeBase64, nil
+}
+
 // SetDefaultFee sets a sensible minimum default for the Transaction fee, if one has not
 // already been set. It is a linear function of the number of Operations in the Transaction.
-func (tx *Transaction) SetDefaultFee() {
-	// TODO: Check if default base fee used elsewhere - otherwise just use int
-	// TODO: Generalise to pull this from a client call
-	var DefaultBaseFee uint64 = 100
-	if tx.BaseFee == 0 {
-		tx.BaseFee = DefaultBaseFee
+// If no BaseFee is set, it is taken from the FeeSource when one is provided. The resolved fee
+// isn't stored in BaseFee, so the FeeSource is queried again on every build.
+func (tx *Transaction) SetDefaultFee() error {
+	baseFee, err := tx.baseFee()
+	if err != nil {
+		return err
 	}
 	if tx.xdrTransaction.Fee == 0 {
-		tx.xdrTransaction.Fee = xdr.Uint32(int(tx.BaseFee) * len(tx.xdrTransaction.Operations))
+		numOps := uint64(len(tx.xdrTransaction.Operations))
+		if numOps > 0 && baseFee > math.MaxUint32/numOps {
+			return errors.Errorf("fee overflows uint32: base fee %d for %d operations", baseFee, numOps)
+		}
+		tx.xdrTransaction.Fee = xdr.Uint32(baseFee * numOps)
+	}
+
+	return nil
+}
+
+// baseFee returns the base fee the Transaction is built with: BaseFee if set, otherwise the
+// fee from the FeeSource if one is provided, otherwise the default base fee.
+func (tx *Transaction) baseFee() (uint64, error) {
+	if tx.BaseFee != 0 {
+		return tx.BaseFee, nil
+	}
+	if tx.FeeSource != nil {
+		baseFee, err := tx.FeeSource.BaseFee()
+		if err != nil {
+			return 0, errors.Wrap(err, "Failed to get base fee from fee source")
+		}
+		return uint64(baseFee), nil
+	}
+
+	return defaultBaseFee, nil
+}
+
+// PerOperationFee returns the effective base fee of the built Transaction, that is its total
+// fee divided by the number of operations. It returns 0 if there are no operations.
+func (tx *Transaction) PerOperationFee() int64 {
+	numOps := int64(len(tx.xdrTransaction.Operations))
+	if numOps == 0 {
+		return 0
 	}
+
+	return int64(tx.xdrTransaction.Fee) / numOps
 }
 
 // Build for Transaction completely configures the Transaction. After calling Build,
-// the Transaction is ready to be serialised or signed.
+// the Transaction is ready to be serialised or signed. If Build fails, the Transaction is
+// left unbuilt.
 func (tx *Transaction) Build() error {
+	err := tx.build()
+	if err != nil {
+		tx.xdrTransaction = xdr.Transaction{}
+	}
+
+	return err
+}
+
+// build sets the XDR transaction from the Transaction's configuration.
+func (tx *Transaction) build() error {
+	// Start from scratch, so that building again doesn't duplicate operations
+	tx.xdrTransaction = xdr.Transaction{}
+
 	// Set account ID in XDR
-	// TODO: Validate provided key before going further
-	tx.xdrTransaction.SourceAccount.SetAddress(tx.SourceAccount.ID)
+	if tx.SourceAccount.ID == "" {
+		return errors.New("Transaction source account must be set")
+	}
+	err := tx.xdrTransaction.SourceAccount.SetAddress(tx.SourceAccount.ID)
+	if err != nil {
+		return errors.Wrap(err, withValue("Failed to set source account", tx.SourceAccount.ID))
+	}
 
 	// TODO: Validate Seq Num is present in struct
-	tx.xdrTransaction.SeqNum = tx.SourceAccount.SequenceNumber + 1
+	tx.xdrTransaction.SeqNum = tx.sequenceNumber()
+
+	if tx.Memo != nil {
+		xdrMemo, err := tx.Memo.ToXDR()
+		if err != nil {
+			return errors.Wrap(err, "Failed to build memo")
+		}
+		tx.xdrTransaction.Memo = xdrMemo
+	}
+
+	if !tx.Timebounds.IsZero() {
+		err := tx.Timebounds.Validate()
+		if err != nil {
+			return errors.Wrap(err, "Invalid time bounds")
+		}
+		xdrTimeBounds := tx.Timebounds.ToXDR()
+		tx.xdrTransaction.TimeBounds = &xdrTimeBounds
+	}
+
+	err = tx.validate()
+	if err != nil {
+		return err
+	}
 
 	for _, op := range tx.Operations {
 		xdrOperation, err := op.BuildXDR()
@@ -86,7 +470,379 @@ func (tx *Transaction) Build() error {
 	}
 
 	// Set a default fee, if it hasn't been set yet
-	tx.SetDefaultFee()
+	err = tx.SetDefaultFee()
+	if err != nil {
+		return errors.Wrap(err, "Failed to set fee")
+	}
+
+	return nil
+}
+
+// Reset discards the built XDR transaction and any signatures, while retaining the
+// Transaction configuration. This allows the same Transaction to be rebuilt, for example
+// after updating the source account sequence number.
+func (tx *Transaction) Reset() {
+	tx.xdrTransaction = xdr.Transaction{}
+	tx.xdrEnvelope = nil
+}
+
+// WithSequence returns a copy of the built Transaction using the given sequence number, with
+// no signatures. It is intended for resubmitting a Transaction that failed, and the copy must
+// be signed again before submission.
+func (tx *Transaction) WithSequence(seq int64) *Transaction {
+	clone := *tx
+	clone.Operations = append([]Operation(nil), tx.Operations...)
+	clone.xdrTransaction.Operations = append([]xdr.Operation(nil), tx.xdrTransaction.Operations...)
+	clone.xdrTransaction.SeqNum = xdr.SequenceNumber(seq)
+	clone.SourceAccount.SequenceNumber = xdr.SequenceNumber(seq - 1)
+	if clone.SequenceNumber != 0 {
+		clone.SequenceNumber = seq
+	}
+	clone.xdrEnvelope = nil
+
+	return &clone
+}
+
+// WithBaseFee returns a copy of the built Transaction using the given base fee, with no
+// signatures. It is intended for resubmitting a Transaction with a higher fee, and the copy
+// must be signed again before submission. It returns an error if the total fee overflows uint32.
+func (tx *Transaction) WithBaseFee(fee uint32) (*Transaction, error) {
+	numOps := uint64(len(tx.xdrTransaction.Operations))
+	if numOps > 0 && uint64(fee) > math.MaxUint32/numOps {
+		return nil, errors.Errorf("fee overflows uint32: base fee %d for %d operations", fee, numOps)
+	}
+
+	clone := *tx
+	clone.Operations = append([]Operation(nil), tx.Operations...)
+	clone.xdrTransaction.Operations = append([]xdr.Operation(nil), tx.xdrTransaction.Operations...)
+	clone.BaseFee = uint64(fee)
+	clone.xdrTransaction.Fee = xdr.Uint32(uint64(fee) * numOps)
+	clone.xdrEnvelope = nil
+
+	return &clone, nil
+}
+
+// ReplaceOperation returns a copy of the Transaction with the operation at the given index
+// replaced by op. The copy is unbuilt and has no signatures, so it must be built and signed
+// again before submission. The original Transaction is unchanged.
+func (tx *Transaction) ReplaceOperation(index int, op Operation) (*Transaction, error) {
+	if index < 0 || index >= len(tx.Operations) {
+		return nil, errors.Errorf("operation index %d out of range for %d operations", index, len(tx.Operations))
+	}
+	if op == nil {
+		return nil, errors.New("replacement operation can't be nil")
+	}
+
+	clone := *tx
+	clone.Operations = append([]Operation(nil), tx.Operations...)
+	clone.Operations[index] = op
+	clone.Reset()
+
+	return &clone, nil
+}
+
+// Summary returns a human readable overview of the Transaction: its source account, sequence
+// number, fee and memo, followed by a description of each operation. Once the Transaction
+// has been built, the total fee is shown; before then, the base fee.
+func (tx *Transaction) Summary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Source: %s\n", tx.SourceAccount.ID)
+	fmt.Fprintf(&b, "Sequence: %d\n", tx.sequenceNumber())
+	if tx.xdrTransaction.Fee != 0 {
+		fmt.Fprintf(&b, "Fee: %d stroops\n", tx.xdrTransaction.Fee)
+	} else if tx.BaseFee != 0 {
+		fmt.Fprintf(&b, "Base fee: %d stroops\n", tx.BaseFee)
+	} else if tx.FeeSource != nil {
+		fmt.Fprintf(&b, "Base fee: from fee source\n")
+	} else {
+		fmt.Fprintf(&b, "Base fee: %d stroops\n", defaultBaseFee)
+	}
+	fmt.Fprintf(&b, "Memo: %s\n", describeMemo(tx.Memo))
+	fmt.Fprintf(&b, "Operations:\n")
+	for _, op := range tx.Operations {
+		fmt.Fprintf(&b, "- %s\n", describeOperation(op))
+	}
+
+	return b.String()
+}
+
+// OperationTypes returns the XDR type of each of the Transaction's operations, in order.
+func (tx *Transaction) OperationTypes() ([]xdr.OperationType, error) {
+	var opTypes []xdr.OperationType
+	for _, op := range tx.Operations {
+		opType, err := operationType(op)
+		if err != nil {
+			return nil, err
+		}
+		opTypes = append(opTypes, opType)
+	}
+
+	return opTypes, nil
+}
+
+// OperationTypeCounts returns the number of the Transaction's operations of each XDR type.
+func (tx *Transaction) OperationTypeCounts() (map[xdr.OperationType]int, error) {
+	counts := map[xdr.OperationType]int{}
+	for _, op := range tx.Operations {
+		opType, err := operationType(op)
+		if err != nil {
+			return nil, err
+		}
+		counts[opType]++
+	}
+
+	return counts, nil
+}
+
+// ModifiesAuthorization returns true if any of the Transaction's operations changes the
+// signers, thresholds or flags of an account, or the authorization of a trustline.
+func (tx *Transaction) ModifiesAuthorization() bool {
+	for _, op := range tx.Operations {
+		switch o := op.(type) {
+		case *SetOptions:
+			if o.changesAuthorization() {
+				return true
+			}
+		case *AllowTrust:
+			return true
+		}
+	}
+
+	return false
+}
+
+// Destinations returns the deduplicated addresses that the Transaction's operations send
+// value to, or whose trustlines they authorize, in the order they first appear.
+func (tx *Transaction) Destinations() []string {
+	var destinations []string
+	seen := map[string]bool{}
+	for _, op := range tx.Operations {
+		var destination string
+		switch o := op.(type) {
+		case *Payment:
+			destination = o.Destination
+		case *CreateAccount:
+			destination = o.Destination
+		case *AccountMerge:
+			destination = o.Destination
+		case *AllowTrust:
+			destination = o.Trustor
+		default:
+			continue
+		}
+
+		if !seen[destination] {
+			seen[destination] = true
+			destinations = append(destinations, destination)
+		}
+	}
+
+	return destinations
+}
+
+// checkNotExpired returns an error if the Transaction's max time has already passed.
+func (tx *Transaction) checkNotExpired() error {
+	return tx.Timebounds.validateNotExpired(time.Now())
+}
+
+// checkDuplicateDataNames returns an error naming the first data entry that is managed by
+// more than one ManageData operation in the Transaction.
+func (tx *Transaction) checkDuplicateDataNames() error {
+	seen := map[string]bool{}
+	for _, op := range tx.Operations {
+		md, ok := op.(*ManageData)
+		if !ok {
+			continue
+		}
+		if seen[md.Name] {
+			return errors.Errorf("duplicate data entry name %q", md.Name)
+		}
+		seen[md.Name] = true
+	}
+
+	return nil
+}
+
+// Assets returns the deduplicated assets that the Transaction's operations send, trade or
+// trust, in the order they first appear.
+func (tx *Transaction) Assets() []Asset {
+	var assets []Asset
+	seen := map[Asset]bool{}
+	add := func(asset Asset) {
+		if !seen[asset] {
+			seen[asset] = true
+			assets = append(assets, asset)
+		}
+	}
+
+	for _, op := range tx.Operations {
+		switch o := op.(type) {
+		case *Payment:
+			add(o.Asset)
+		case *CreateAccount:
+			add(NewNativeAsset())
+		case *ChangeTrust:
+			add(o.Line)
+		case *ManageSellOffer:
+			add(o.Selling)
+			add(o.Buying)
+		}
+	}
+
+	return assets
+}
+
+// TotalNativeMoved returns the total amount of lumens, in stroops, sent by the Transaction's
+// payment and create account operations. Payments of other assets are ignored, as are account
+// merges, whose amount isn't known until the Transaction is applied.
+func (tx *Transaction) TotalNativeMoved() (int64, error) {
+	var total int64
+	for i, op := range tx.Operations {
+		var amt string
+		switch o := op.(type) {
+		case *Payment:
+			if !o.Asset.IsNative() {
+				continue
+			}
+			amt = o.Amount
+		case *CreateAccount:
+			amt = o.Amount
+		default:
+			continue
+		}
+
+		stroops, err := amount.ParseInt64(amt)
+		if err != nil {
+			return 0, errors.Wrapf(err, "Failed to parse amount of operation %d", i)
+		}
+		if stroops > math.MaxInt64-total {
+			return 0, errors.New("total amount overflows int64")
+		}
+		total += stroops
+	}
+
+	return total, nil
+}
+
+// checkSelfInflation returns an error if a SetOptions operation sets the inflation
+// destination to its own source account. This is allowed by the network, but is usually a
+// mistake.
+func (tx *Transaction) checkSelfInflation() error {
+	for i, op := range tx.Operations {
+		so, ok := op.(*SetOptions)
+		if !ok || so.InflationDestination == nil {
+			continue
+		}
+
+		source := so.SourceAccount
+		if source == "" {
+			source = tx.SourceAccount.ID
+		}
+		if *so.InflationDestination == source {
+			return errors.Errorf("operation %d sets the inflation destination to its own source account %s", i, source)
+		}
+	}
+
+	return nil
+}
+
+// checkZeroFlags returns an error if any SetOptions operation sets or clears AccountFlag(0).
+func (tx *Transaction) checkZeroFlags() error {
+	for i, op := range tx.Operations {
+		so, ok := op.(*SetOptions)
+		if !ok {
+			continue
+		}
+
+		for _, flag := range so.SetFlags {
+			if flag == 0 {
+				return errors.Errorf("operation %d has a zero flag in SetFlags, which is not a real flag", i)
+			}
+		}
+		for _, flag := range so.ClearFlags {
+			if flag == 0 {
+				return errors.Errorf("operation %d has a zero flag in ClearFlags, which is not a real flag", i)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkSignerCount returns an error if the Transaction's SetOptions operations add more than
+// MaxSignersPerAccount distinct signers to any one account. Signers the account already has
+// are unknown, so this only catches a Transaction that could never succeed.
+func (tx *Transaction) checkSignerCount() error {
+	added := map[string]map[string]bool{}
+	for _, op := range tx.Operations {
+		so, ok := op.(*SetOptions)
+		if !ok || so.Signer == nil || so.Signer.Weight == 0 {
+			continue
+		}
+
+		source := so.SourceAccount
+		if source == "" {
+			source = tx.SourceAccount.ID
+		}
+		if added[source] == nil {
+			added[source] = map[string]bool{}
+		}
+		added[source][so.Signer.Address] = true
+
+		if len(added[source]) > MaxSignersPerAccount {
+			return errors.Errorf("transaction adds more than %d signers to account %s", MaxSignersPerAccount, source)
+		}
+	}
+
+	return nil
+}
+
+// CheckOperationSources returns an error naming the first operation whose source account is
+// not among the known accounts, for example those for which signing keys are available.
+// Operations without their own source account use the Transaction's source account and are
+// not checked.
+func CheckOperationSources(tx *Transaction, knownAccounts []string) error {
+	known := map[string]bool{}
+	for _, account := range knownAccounts {
+		known[account] = true
+	}
+
+	for i, op := range tx.Operations {
+		source := op.GetSourceAccount()
+		if source != "" && !known[source] {
+			return errors.Errorf("operation %d (%T) has unknown source account %s", i, op, source)
+		}
+	}
+
+	return nil
+}
+
+// CheckAuthImmutableConflicts returns an error if an operation that sets the AuthImmutable
+// flag on an account is followed, in the same Transaction, by an operation that changes the
+// same account's flags, signers or thresholds, or merges it. Once an account is immutable,
+// such changes are either rejected or almost certainly unintended.
+func (tx *Transaction) CheckAuthImmutableConflicts() error {
+	immutable := map[string]bool{}
+	for i, op := range tx.Operations {
+		source := op.GetSourceAccount()
+		if source == "" {
+			source = tx.SourceAccount.ID
+		}
+
+		switch o := op.(type) {
+		case *SetOptions:
+			if immutable[source] && o.changesAuthorization() {
+				return errors.Errorf("operation %d changes account %s after it was made immutable", i, source)
+			}
+			if CombineFlags(o.SetFlags...)&xdr.Uint32(AuthImmutable) != 0 {
+				immutable[source] = true
+			}
+		case *AccountMerge:
+			if immutable[source] {
+				return errors.Errorf("operation %d merges account %s after it was made immutable", i, source)
+			}
+		}
+	}
 
 	return nil
 }
@@ -120,3 +876,198 @@ func (tx *Transaction) Sign(kp *keypair.Full) error {
 
 	return nil
 }
+
+// SignAndVerify signs the Transaction like Sign, then checks the new signature against kp
+// before returning. If the check fails the signature is removed again. It is intended for
+// test harnesses, to catch hashing or signing regressions as early as possible.
+func (tx *Transaction) SignAndVerify(kp *keypair.Full) error {
+	err := tx.Sign(kp)
+	if err != nil {
+		return err
+	}
+
+	signatures := tx.xdrEnvelope.Signatures
+	sig := signatures[len(signatures)-1]
+	hash, err := tx.Hash()
+	if err != nil {
+		return errors.Wrap(err, "Failed to hash transaction")
+	}
+	if !verifyDecoratedSignature(hash, sig, []keypair.KP{kp}) {
+		tx.xdrEnvelope.Signatures = signatures[:len(signatures)-1]
+		return errors.New("signature does not verify against the signing key")
+	}
+
+	return nil
+}
+
+// AddDecoratedSignature appends a decorated signature produced elsewhere, for example by
+// other tooling, to the Transaction envelope. The signature itself is not verified.
+func (tx *Transaction) AddDecoratedSignature(sig xdr.DecoratedSignature) error {
+	if len(sig.Signature) != ed25519.SignatureSize {
+		return errors.Errorf("signature must be %d bytes, got %d", ed25519.SignatureSize, len(sig.Signature))
+	}
+
+	// Initialise transaction envelope
+	if tx.xdrEnvelope == nil {
+		tx.xdrEnvelope = &xdr.TransactionEnvelope{}
+		tx.xdrEnvelope.Tx = tx.xdrTransaction
+	}
+
+	tx.xdrEnvelope.Signatures = append(tx.xdrEnvelope.Signatures, sig)
+
+	return nil
+}
+
+// MaxSignedPayloadLength is the maximum length in bytes of a payload signed by SignWithPayload.
+const MaxSignedPayloadLength = 64
+
+// SignWithPayload signs the given payload, rather than the Transaction hash, and appends the
+// decorated signature to the Transaction envelope. This satisfies an Ed25519 signed payload
+// signer (CAP-40), whose signature hint is the key's hint XORed with the last 4 bytes of the
+// payload.
+func (tx *Transaction) SignWithPayload(kp *keypair.Full, payload []byte) error {
+	if len(payload) > MaxSignedPayloadLength {
+		return errors.Errorf("payload can't be longer than %d bytes", MaxSignedPayloadLength)
+	}
+
+	// Initialise transaction envelope
+	if tx.xdrEnvelope == nil {
+		tx.xdrEnvelope = &xdr.TransactionEnvelope{}
+		tx.xdrEnvelope.Tx = tx.xdrTransaction
+	}
+
+	sig, err := kp.Sign(payload)
+	if err != nil {
+		return errors.Wrap(err, "Failed to sign payload")
+	}
+
+	tx.xdrEnvelope.Signatures = append(tx.xdrEnvelope.Signatures, xdr.DecoratedSignature{
+		Hint:      signedPayloadHint(kp.Hint(), payload),
+		Signature: xdr.Signature(sig),
+	})
+
+	return nil
+}
+
+// signedPayloadHint returns the CAP-40 signature hint for a payload signed by a key with the
+// given hint. Payloads shorter than 4 bytes are padded with zeros.
+func signedPayloadHint(keyHint [4]byte, payload []byte) xdr.SignatureHint {
+	var payloadHint [4]byte
+	if len(payload) >= 4 {
+		copy(payloadHint[:], payload[len(payload)-4:])
+	} else {
+		copy(payloadHint[:], payload)
+	}
+
+	var hint xdr.SignatureHint
+	for i := range hint {
+		hint[i] = keyHint[i] ^ payloadHint[i]
+	}
+	return hint
+}
+
+// DedupeSignatures removes duplicate decorated signatures (those with the same hint and
+// signature bytes) from the Transaction envelope, keeping the first occurrence of each.
+func (tx *Transaction) DedupeSignatures() {
+	if tx.xdrEnvelope == nil {
+		return
+	}
+
+	var signatures []xdr.DecoratedSignature
+	seen := map[string]bool{}
+	for _, sig := range tx.xdrEnvelope.Signatures {
+		key := string(sig.Hint[:]) + string(sig.Signature)
+		if !seen[key] {
+			seen[key] = true
+			signatures = append(signatures, sig)
+		}
+	}
+	tx.xdrEnvelope.Signatures = signatures
+}
+
+// SortSignatures sorts the signatures on the Transaction envelope by hint, then by signature
+// bytes, so that envelopes with the same set of signatures are encoded identically.
+func (tx *Transaction) SortSignatures() {
+	if tx.xdrEnvelope == nil {
+		return
+	}
+
+	signatures := tx.xdrEnvelope.Signatures
+	sort.Slice(signatures, func(i, j int) bool {
+		if c := bytes.Compare(signatures[i].Hint[:], signatures[j].Hint[:]); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(signatures[i].Signature, signatures[j].Signature) < 0
+	})
+}
+
+// VerifySignatures checks that every signature on the Transaction envelope is a valid
+// signature by one of the given signers. The Transaction hash is computed once and shared
+// by all of the checks.
+func (tx *Transaction) VerifySignatures(signers ...keypair.KP) error {
+	if tx.xdrEnvelope == nil {
+		return nil
+	}
+
+	hash, err := tx.Hash()
+	if err != nil {
+		return errors.Wrap(err, "Failed to hash transaction")
+	}
+
+	for i, sig := range tx.xdrEnvelope.Signatures {
+		if !verifyDecoratedSignature(hash, sig, signers) {
+			return errors.Errorf("signature %d is not valid for any of the given signers", i)
+		}
+	}
+
+	return nil
+}
+
+// SignersPresent reports, for each of the candidate addresses, whether the Transaction
+// envelope carries a valid signature by that address. Candidates that are not valid
+// addresses are reported as not present. This is useful for showing the progress of
+// collecting signatures for a multi-signature transaction.
+func (tx *Transaction) SignersPresent(candidates []string) map[string]bool {
+	present := make(map[string]bool, len(candidates))
+	for _, candidate := range candidates {
+		present[candidate] = false
+	}
+	if tx.xdrEnvelope == nil || len(tx.xdrEnvelope.Signatures) == 0 {
+		return present
+	}
+
+	hash, err := tx.Hash()
+	if err != nil {
+		return present
+	}
+
+	for _, candidate := range candidates {
+		kp, err := keypair.Parse(candidate)
+		if err != nil {
+			continue
+		}
+		for _, sig := range tx.xdrEnvelope.Signatures {
+			if verifyDecoratedSignature(hash, sig, []keypair.KP{kp}) {
+				present[candidate] = true
+				break
+			}
+		}
+	}
+
+	return present
+}
+
+// verifyDecoratedSignature returns true if the signature is a valid signature of the hash by
+// one of the signers whose hint matches the signature's hint.
+func verifyDecoratedSignature(hash [32]byte, sig xdr.DecoratedSignature, signers []keypair.KP) bool {
+	for _, signer := range signers {
+		if signer.Hint() != sig.Hint {
+			continue
+		}
+		if signer.Verify(hash[:], sig.Signature) == nil {
+			return true
+		}
+	}
+
+	return false
+}
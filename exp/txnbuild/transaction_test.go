@@ -1,13 +1,27 @@
 package txnbuild
 
 import (
+	"bytes"
+	"encoding/base64"
 	"testing"
 
+	"github.com/stellar/go/hash"
 	"github.com/stellar/go/keypair"
 	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
 	"github.com/stretchr/testify/assert"
 )
 
+// newTestTx returns an unbuilt Transaction on the test network with the given operations,
+// whose source account is newKeypair0.
+func newTestTx(ops ...Operation) *Transaction {
+	return &Transaction{
+		SourceAccount: Account{ID: newKeypair0().Address(), SequenceNumber: 9605939170639897},
+		Operations:    ops,
+		Network:       network.TestNetworkPassphrase,
+	}
+}
+
 func newKeypair0() *keypair.Full {
 	return newKeypair("SBPQUZ6G4FZNWFHKUWC5BEYWF6R52E3SEP7R3GWYSM2XTKGF5LNTWW4R")
 }
@@ -80,6 +94,48 @@ func TestCreateAccount(t *testing.T) {
 	assert.Equal(t, expected, received, "Base 64 XDR should match")
 }
 
+func TestNewAccountSetupTx(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+	sourceAccount := Account{
+		ID:             kp0.Address(),
+		SequenceNumber: 9605939170639897,
+	}
+	usd := Asset{Code: "USD", Issuer: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"}
+	homeDomain := "example.com"
+	options := &SetOptions{HomeDomain: &homeDomain}
+
+	tx, err := NewAccountSetupTx(sourceAccount, network.TestNetworkPassphrase, kp1.Address(), "10", []Asset{usd}, options, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "", options.SourceAccount, "Given options should be unchanged")
+
+	xdrTx, err := tx.ToXDR()
+	assert.Nil(t, err)
+	if assert.Len(t, xdrTx.Operations, 3) {
+		createAccount := xdrTx.Operations[0]
+		assert.Nil(t, createAccount.SourceAccount)
+		createAccountOp := createAccount.Body.MustCreateAccountOp()
+		assert.Equal(t, kp1.Address(), createAccountOp.Destination.Address())
+		assert.Equal(t, xdr.Int64(100000000), createAccountOp.StartingBalance)
+
+		changeTrust := xdrTx.Operations[1]
+		assert.Equal(t, kp1.Address(), changeTrust.SourceAccount.Address())
+		assert.Equal(t, xdr.AssetTypeAssetTypeCreditAlphanum4, changeTrust.Body.MustChangeTrustOp().Line.Type)
+
+		setOptions := xdrTx.Operations[2]
+		assert.Equal(t, kp1.Address(), setOptions.SourceAccount.Address())
+		assert.Equal(t, xdr.String32(homeDomain), *setOptions.Body.MustSetOptionsOp().HomeDomain)
+	}
+	assert.Equal(t, xdr.Uint32(300), xdrTx.Fee)
+
+	withoutOptions, err := NewAccountSetupTx(sourceAccount, network.TestNetworkPassphrase, kp1.Address(), "10", nil, nil, 0)
+	assert.Nil(t, err)
+	assert.Len(t, withoutOptions.Operations, 1)
+
+	_, err = NewAccountSetupTx(sourceAccount, network.TestNetworkPassphrase, kp1.Address(), "10", []Asset{NewNativeAsset()}, nil, 0)
+	assert.Error(t, err)
+}
+
 func TestPayment(t *testing.T) {
 	kp0 := newKeypair0()
 	sourceAccount := Account{
@@ -103,6 +159,84 @@ func TestPayment(t *testing.T) {
 	assert.Equal(t, expected, received, "Base 64 XDR should match")
 }
 
+func TestNewPaymentTx(t *testing.T) {
+	kp0 := newKeypair0()
+	sourceAccount := Account{
+		ID:             kp0.Address(),
+		SequenceNumber: 9605939170639898,
+	}
+	dest := "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"
+
+	tx, err := NewPaymentTx(sourceAccount, network.TestNetworkPassphrase, dest, "10", NewNativeAsset(), MemoText("deposit"), 200)
+	assert.Nil(t, err)
+
+	expected := Transaction{
+		SourceAccount: sourceAccount,
+		Operations:    []Operation{&Payment{Destination: dest, Amount: "10", Asset: NewNativeAsset()}},
+		BaseFee:       200,
+		Network:       network.TestNetworkPassphrase,
+		Memo:          MemoText("deposit"),
+	}
+	err = expected.Build()
+	assert.Nil(t, err)
+
+	received, err := xdr.MarshalBase64(tx.xdrTransaction)
+	assert.Nil(t, err)
+	expectedB64, err := xdr.MarshalBase64(expected.xdrTransaction)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedB64, received)
+
+	xdrTx, err := tx.ToXDR()
+	assert.Nil(t, err)
+	assert.Len(t, xdrTx.Operations, 1)
+
+	txeB64, err := tx.UnsignedBase64()
+	assert.Nil(t, err)
+	var envelope xdr.TransactionEnvelope
+	err = xdr.SafeUnmarshalBase64(txeB64, &envelope)
+	assert.Nil(t, err)
+	assert.Len(t, envelope.Tx.Operations, 1)
+	assert.Equal(t, xdr.MemoTypeMemoText, xdrTx.Memo.Type)
+	assert.Equal(t, "deposit", *xdrTx.Memo.Text)
+	assert.Equal(t, xdr.Uint32(200), xdrTx.Fee)
+
+	_, err = NewPaymentTx(sourceAccount, network.TestNetworkPassphrase, "invalid", "10", NewNativeAsset(), nil, 0)
+	assert.Error(t, err)
+}
+
+func TestBuildPaymentBatch(t *testing.T) {
+	kp0 := newKeypair0()
+	sourceAccount := Account{
+		ID:             kp0.Address(),
+		SequenceNumber: 9605939170639898,
+	}
+
+	payments := make([]Payment, 250)
+	for i := range payments {
+		payments[i] = Payment{
+			Destination: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H",
+			Amount:      "1",
+			Asset:       NewNativeAsset(),
+		}
+	}
+
+	txs, err := BuildPaymentBatch(sourceAccount, network.TestNetworkPassphrase, payments, 100, MaxOperationsPerTransaction)
+	assert.Nil(t, err)
+	if assert.Len(t, txs, 3) {
+		for i, expectedOps := range []int{100, 100, 50} {
+			xdrTx, err := txs[i].ToXDR()
+			assert.Nil(t, err)
+			assert.Len(t, xdrTx.Operations, expectedOps)
+			assert.Equal(t, sourceAccount.SequenceNumber+xdr.SequenceNumber(i+1), xdrTx.SeqNum)
+		}
+	}
+
+	_, err = BuildPaymentBatch(sourceAccount, network.TestNetworkPassphrase, payments, 100, MaxOperationsPerTransaction+1)
+	assert.Error(t, err)
+	_, err = BuildPaymentBatch(sourceAccount, network.TestNetworkPassphrase, payments, 100, 0)
+	assert.Error(t, err)
+}
+
 func TestBumpSequence(t *testing.T) {
 	kp1 := newKeypair1()
 	sourceAccount := Account{
@@ -147,3 +281,1160 @@ func TestMultipleOperations(t *testing.T) {
 	expected := "AAAAACXK8doPx27P6IReQlRRuweSSUiUfjqgyswxiu3Sh2R+AAAAyAAiILoAAAAIAAAAAAAAAAAAAAACAAAAAAAAAAkAAAAAAAAACwAiILoAAABsAAAAAAAAAAHSh2R+AAAAQGx5xAPuF3rH3/KSHXduYYvE/Qw4CAseF2F0oSacIYi8e320OW07lr9VF8XEcDqMSVNhkFopoh5P0ZSixcTxyQI="
 	assert.Equal(t, expected, received, "Base 64 XDR should match")
 }
+
+func TestSizeBytes(t *testing.T) {
+	kp0 := newKeypair0()
+	sourceAccount := Account{
+		ID:             kp0.Address(),
+		SequenceNumber: 9605939170639898,
+	}
+
+	payment := Payment{
+		Destination: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H",
+		Amount:      "10",
+	}
+
+	small := Transaction{
+		SourceAccount: sourceAccount,
+		Operations:    []Operation{&payment},
+		Network:       network.TestNetworkPassphrase,
+	}
+	err := small.Build()
+	assert.Nil(t, err)
+
+	unsignedSize, err := small.SizeBytes()
+	assert.Nil(t, err)
+
+	err = small.Sign(kp0)
+	assert.Nil(t, err)
+
+	txBytes, err := small.MarshalBinary()
+	assert.Nil(t, err)
+	size, err := small.SizeBytes()
+	assert.Nil(t, err)
+	assert.Equal(t, len(txBytes), size, "Size should match marshaled envelope")
+	assert.True(t, unsignedSize < size, "Signature should be included in size")
+
+	var operations []Operation
+	for i := 0; i < 100; i++ {
+		operations = append(operations, &Payment{
+			Destination: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H",
+			Amount:      "10",
+		})
+	}
+	large := Transaction{
+		SourceAccount: sourceAccount,
+		Operations:    operations,
+		Network:       network.TestNetworkPassphrase,
+	}
+	err = large.Build()
+	assert.Nil(t, err)
+	err = large.Sign(kp0)
+	assert.Nil(t, err)
+
+	txBytes, err = large.MarshalBinary()
+	assert.Nil(t, err)
+	size, err = large.SizeBytes()
+	assert.Nil(t, err)
+	assert.Equal(t, len(txBytes), size, "Size should match marshaled envelope")
+}
+
+func TestAccountMerge(t *testing.T) {
+	kp0 := newKeypair0()
+	sourceAccount := Account{
+		ID:             kp0.Address(),
+		SequenceNumber: 9605939170639898,
+	}
+
+	accountMerge := AccountMerge{
+		Destination: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H",
+	}
+
+	tx := Transaction{
+		SourceAccount: sourceAccount,
+		Operations:    []Operation{&accountMerge},
+		Network:       network.TestNetworkPassphrase,
+	}
+
+	err := tx.Build()
+	assert.Nil(t, err)
+
+	body := tx.xdrTransaction.Operations[0].Body
+	assert.Equal(t, xdr.OperationTypeAccountMerge, body.Type)
+	destination := body.MustDestination()
+	assert.Equal(t, accountMerge.Destination, destination.Address())
+}
+
+func TestDestinations(t *testing.T) {
+	kp0 := newKeypair0()
+	dest1 := "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"
+	dest2 := "GCCOBXW2XQNUSL467IEILE6MMCNRR66SSVL4YQADUNYYNUVREF3FIV2Z"
+
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 1},
+		Operations: []Operation{
+			&Payment{Destination: dest1, Amount: "10"},
+			&Inflation{},
+			&Payment{Destination: dest1, Amount: "5"},
+			&AccountMerge{Destination: dest2},
+		},
+		Network: network.TestNetworkPassphrase,
+	}
+
+	assert.Equal(t, []string{dest1, dest2}, tx.Destinations())
+
+	trustor := newKeypair1().Address()
+	tx.Operations = append(tx.Operations, &AllowTrust{Trustor: trustor, Type: Asset{Code: "USD", Issuer: kp0.Address()}, Authorize: true})
+	assert.Equal(t, []string{dest1, dest2, trustor}, tx.Destinations())
+}
+
+func TestBuildRequiresSourceAccount(t *testing.T) {
+	missing := Transaction{
+		Operations: []Operation{&Inflation{}},
+		Network:    network.TestNetworkPassphrase,
+	}
+	err := missing.Build()
+	assert.EqualError(t, err, "Transaction source account must be set")
+
+	invalid := Transaction{
+		SourceAccount: Account{ID: "GBADADDRESS", SequenceNumber: 1},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	err = invalid.Build()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Failed to set source account")
+	}
+
+	present := Transaction{
+		SourceAccount: Account{ID: newKeypair0().Address(), SequenceNumber: 1},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	assert.Nil(t, present.Build())
+}
+
+func TestReset(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+
+	err := tx.Build()
+	assert.Nil(t, err)
+	err = tx.Sign(kp0)
+	assert.Nil(t, err)
+
+	tx.Reset()
+	assert.Nil(t, tx.xdrEnvelope, "Envelope should be discarded")
+
+	tx.SourceAccount.SequenceNumber = 9605939170639910
+	err = tx.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.SequenceNumber(9605939170639911), tx.xdrTransaction.SeqNum)
+	assert.Equal(t, 1, len(tx.xdrTransaction.Operations), "Operations should not be duplicated")
+	assert.Equal(t, xdr.Uint32(100), tx.xdrTransaction.Fee)
+
+	err = tx.Sign(kp0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(tx.xdrEnvelope.Signatures))
+	assert.Equal(t, xdr.SequenceNumber(9605939170639911), tx.xdrEnvelope.Tx.SeqNum)
+}
+
+func TestMarshalBinaryRoundTrip(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}, &BumpSequence{BumpTo: 10}},
+		Network:       network.TestNetworkPassphrase,
+		Memo:          MemoText("round trip"),
+		Timebounds:    NewTimebounds(0, 1600000000),
+	}
+
+	err := tx.Build()
+	assert.Nil(t, err)
+	err = tx.Sign(kp0)
+	assert.Nil(t, err)
+
+	txBytes, err := tx.MarshalBinary()
+	assert.Nil(t, err)
+
+	// Existing operations on the receiver are replaced, not appended to
+	decoded := Transaction{Network: network.TestNetworkPassphrase, Operations: []Operation{&Inflation{}}}
+	err = decoded.UnmarshalBinary(txBytes)
+	assert.Nil(t, err)
+	assert.Equal(t, tx.SourceAccount, decoded.SourceAccount)
+	assert.Equal(t, tx.xdrTransaction, decoded.xdrTransaction)
+	assert.Equal(t, 1, len(decoded.xdrEnvelope.Signatures))
+	if assert.Len(t, decoded.Operations, 2) {
+		assert.IsType(t, &Inflation{}, decoded.Operations[0])
+		assert.Equal(t, int64(10), decoded.Operations[1].(*BumpSequence).BumpTo)
+	}
+	assert.Equal(t, MemoText("round trip"), decoded.Memo)
+	assert.Equal(t, uint64(100), decoded.BaseFee)
+	assert.Equal(t, tx.Timebounds, decoded.Timebounds)
+	assert.Equal(t, network.TestNetworkPassphrase, string(decoded.Network))
+
+	decodedBytes, err := decoded.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, txBytes, decodedBytes, "Round trip should produce identical bytes")
+
+	err = decoded.UnmarshalBinary([]byte{0x01, 0x02})
+	assert.Error(t, err)
+}
+
+func TestTransactionFromEnvelope(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+	original := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	err := original.Build()
+	assert.Nil(t, err)
+	err = original.Sign(kp0)
+	assert.Nil(t, err)
+
+	tx, err := TransactionFromEnvelope(*original.xdrEnvelope, network.TestNetworkPassphrase)
+	assert.Nil(t, err)
+	assert.Equal(t, original.SourceAccount, tx.SourceAccount)
+
+	originalHash, err := original.Hash()
+	assert.Nil(t, err)
+	hash, err := tx.Hash()
+	assert.Nil(t, err)
+	assert.Equal(t, originalHash, hash)
+
+	err = tx.Sign(kp1)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(tx.xdrEnvelope.Signatures))
+	assert.Equal(t, 1, len(original.xdrEnvelope.Signatures), "Original envelope should be unchanged")
+
+	_, err = TransactionFromEnvelope(*original.xdrEnvelope, "")
+	assert.Error(t, err)
+}
+
+func TestUnsignedBase64(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+
+	txeBase64, err := tx.UnsignedBase64()
+	assert.Nil(t, err)
+
+	var envelope xdr.TransactionEnvelope
+	err = xdr.SafeUnmarshalBase64(txeBase64, &envelope)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(envelope.Signatures))
+	assert.Equal(t, 1, len(envelope.Tx.Operations))
+	assert.Equal(t, xdr.SequenceNumber(9605939170639898), envelope.Tx.SeqNum)
+}
+
+func TestUnsignedBase64AlreadyBuilt(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Payment{Destination: newKeypair1().Address(), Amount: "10"}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	err := tx.Build()
+	assert.Nil(t, err)
+
+	txeBase64, err := tx.UnsignedBase64()
+	assert.Nil(t, err)
+
+	var envelope xdr.TransactionEnvelope
+	err = xdr.SafeUnmarshalBase64(txeBase64, &envelope)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(envelope.Tx.Operations), "Operations should not be duplicated")
+	assert.Equal(t, xdr.Uint32(100), envelope.Tx.Fee)
+
+	err = tx.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(tx.xdrTransaction.Operations), "Building again should not duplicate operations")
+	assert.Equal(t, xdr.Uint32(100), tx.xdrTransaction.Fee)
+}
+
+func TestFailedBuildLeavesTransactionUnbuilt(t *testing.T) {
+	tx := newTestTx(&Inflation{}, &Payment{Destination: "GBADADDRESS", Amount: "10"})
+	err := tx.Build()
+	assert.Error(t, err)
+
+	_, err = tx.UnsignedBase64()
+	assert.Error(t, err, "A failed build shouldn't be encoded")
+	_, err = tx.ToXDR()
+	assert.EqualError(t, err, "Transaction has not been built")
+	assert.Contains(t, tx.Summary(), "Base fee: 100 stroops")
+}
+
+func TestDedupeSignatures(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+
+	err := tx.Build()
+	assert.Nil(t, err)
+	err = tx.Sign(kp0)
+	assert.Nil(t, err)
+	err = tx.Sign(kp1)
+	assert.Nil(t, err)
+	err = tx.Sign(kp0)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(tx.xdrEnvelope.Signatures))
+
+	tx.DedupeSignatures()
+	assert.Equal(t, 2, len(tx.xdrEnvelope.Signatures))
+	assert.Equal(t, kp0.Hint(), [4]byte(tx.xdrEnvelope.Signatures[0].Hint))
+	assert.Equal(t, kp1.Hint(), [4]byte(tx.xdrEnvelope.Signatures[1].Hint))
+}
+
+func TestCustomNetwork(t *testing.T) {
+	kp0 := newKeypair0()
+	newTx := func(txNetwork Network) Transaction {
+		return Transaction{
+			SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+			Operations:    []Operation{&Inflation{}},
+			Network:       txNetwork,
+		}
+	}
+
+	customTx := newTx(NewNetwork("Standalone Network ; February 2017"))
+	err := customTx.Build()
+	assert.Nil(t, err)
+	err = customTx.Sign(kp0)
+	assert.Nil(t, err)
+
+	customHash, err := customTx.Hash()
+	assert.Nil(t, err)
+	err = kp0.Verify(customHash[:], customTx.xdrEnvelope.Signatures[0].Signature)
+	assert.Nil(t, err)
+
+	publicTx := newTx(network.PublicNetworkPassphrase)
+	err = publicTx.Build()
+	assert.Nil(t, err)
+	publicHash, err := publicTx.Hash()
+	assert.Nil(t, err)
+	assert.NotEqual(t, publicHash, customHash)
+}
+
+func TestMergeTransactions(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+	sourceAccount := Account{ID: kp0.Address(), SequenceNumber: 9605939170639897}
+
+	tx1 := Transaction{
+		SourceAccount: sourceAccount,
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	err := tx1.Build()
+	assert.Nil(t, err)
+	err = tx1.Sign(kp0)
+	assert.Nil(t, err)
+
+	bumpSequence := BumpSequence{BumpTo: 9605939170639999}
+	tx2 := Transaction{
+		SourceAccount: sourceAccount,
+		Operations:    []Operation{&bumpSequence},
+		Network:       network.TestNetworkPassphrase,
+	}
+
+	merged, err := MergeTransactions(&tx1, &tx2)
+	assert.Nil(t, err)
+	assert.Equal(t, []Operation{tx1.Operations[0], &bumpSequence}, merged.Operations)
+	assert.Nil(t, merged.xdrEnvelope, "Signatures should not be carried over")
+
+	err = merged.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(merged.xdrTransaction.Operations))
+	assert.Equal(t, xdr.Uint32(200), merged.xdrTransaction.Fee)
+
+	tx3 := Transaction{
+		SourceAccount: Account{ID: kp1.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	_, err = MergeTransactions(&tx1, &tx3)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), kp1.Address())
+	}
+}
+
+// sliceMemo is a Memo implementation that can't be compared with ==.
+type sliceMemo []byte
+
+func (m sliceMemo) ToXDR() (xdr.Memo, error) {
+	return xdr.NewMemo(xdr.MemoTypeMemoText, string(m))
+}
+
+func TestMergeTransactionsMemo(t *testing.T) {
+	tx1 := newTestTx(&Inflation{})
+	tx1.Memo = sliceMemo("deposit")
+	tx2 := newTestTx(&Inflation{})
+	tx2.Memo = sliceMemo("deposit")
+
+	merged, err := MergeTransactions(tx1, tx2)
+	assert.Nil(t, err, "Memos that aren't comparable should be compared by their XDR")
+	assert.Equal(t, 2, len(merged.Operations))
+
+	tx2.Memo = MemoText("deposit")
+	_, err = MergeTransactions(tx1, tx2)
+	assert.Nil(t, err, "Memos with the same XDR are the same memo")
+
+	tx2.Memo = sliceMemo("withdrawal")
+	_, err = MergeTransactions(tx1, tx2)
+	assert.EqualError(t, err, "transaction 1 has a different memo to transaction 0")
+
+	tx2.Memo = nil
+	_, err = MergeTransactions(tx1, tx2)
+	assert.EqualError(t, err, "transaction 1 has a different memo to transaction 0")
+}
+
+func TestMergeTransactionsTimebounds(t *testing.T) {
+	tx1 := newTestTx(&Inflation{})
+	tx1.Timebounds = NewTimebounds(0, 1600000000)
+	tx2 := newTestTx(&Inflation{})
+	tx2.Timebounds = NewTimebounds(0, 1600000000)
+
+	merged, err := MergeTransactions(tx1, tx2)
+	assert.Nil(t, err)
+	assert.Equal(t, tx1.Timebounds, merged.Timebounds)
+
+	tx2.Timebounds = NewTimebounds(0, 1700000000)
+	_, err = MergeTransactions(tx1, tx2)
+	assert.EqualError(t, err, "transaction 1 has time bounds 0 to 1700000000, expected 0 to 1600000000")
+}
+
+func TestConflictingSequence(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+	payment := &Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Payment{Destination: kp1.Address(), Amount: "10"}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	inflation := &Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	assert.True(t, ConflictingSequence(payment, inflation))
+
+	offline := &Transaction{
+		SourceAccount:  Account{ID: kp0.Address()},
+		SequenceNumber: 9605939170639898,
+		Operations:     []Operation{&Inflation{}},
+		Network:        network.TestNetworkPassphrase,
+	}
+	assert.True(t, ConflictingSequence(payment, offline), "Supplied sequence numbers should be compared too")
+
+	inflation.SourceAccount.SequenceNumber++
+	assert.False(t, ConflictingSequence(payment, inflation))
+
+	otherSource := &Transaction{
+		SourceAccount: Account{ID: kp1.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	assert.False(t, ConflictingSequence(payment, otherSource))
+}
+
+func TestWithSequence(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	err := tx.Build()
+	assert.Nil(t, err)
+	err = tx.Sign(kp0)
+	assert.Nil(t, err)
+
+	retry := tx.WithSequence(9605939170639920)
+	assert.Equal(t, xdr.SequenceNumber(9605939170639920), retry.xdrTransaction.SeqNum)
+	assert.Equal(t, xdr.SequenceNumber(9605939170639919), retry.SourceAccount.SequenceNumber)
+	assert.Nil(t, retry.xdrEnvelope, "Signatures should be cleared")
+	assert.Equal(t, xdr.SequenceNumber(9605939170639898), tx.xdrTransaction.SeqNum, "Original should be unchanged")
+
+	err = retry.Sign(kp0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(retry.xdrEnvelope.Signatures))
+	assert.Equal(t, xdr.SequenceNumber(9605939170639920), retry.xdrEnvelope.Tx.SeqNum)
+}
+
+func TestWithBaseFee(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}, &BumpSequence{BumpTo: 10}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	err := tx.Build()
+	assert.Nil(t, err)
+	err = tx.Sign(kp0)
+	assert.Nil(t, err)
+
+	retry, err := tx.WithBaseFee(500)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(500), retry.BaseFee)
+	assert.Equal(t, xdr.Uint32(1000), retry.xdrTransaction.Fee)
+	assert.Equal(t, int64(500), retry.PerOperationFee())
+	assert.Nil(t, retry.xdrEnvelope, "Signatures should be cleared")
+	assert.Equal(t, xdr.Uint32(200), tx.xdrTransaction.Fee, "Original should be unchanged")
+	assert.Len(t, tx.xdrEnvelope.Signatures, 1)
+
+	err = retry.Sign(kp0)
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.Uint32(1000), retry.xdrEnvelope.Tx.Fee)
+
+	_, err = tx.WithBaseFee(3000000000)
+	assert.EqualError(t, err, "fee overflows uint32: base fee 3000000000 for 2 operations")
+}
+
+func TestBuildWithSequenceNumber(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount:  Account{ID: kp0.Address()},
+		SequenceNumber: 9605939170639898,
+		Operations:     []Operation{&Inflation{}},
+		Network:        network.TestNetworkPassphrase,
+	}
+	// The same transaction built from the account's current sequence number
+	fromAccount := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	assert.Equal(t, buildSignEncode(fromAccount, kp0, t), buildSignEncode(tx, kp0, t))
+
+	err := tx.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.SequenceNumber(9605939170639898), tx.xdrTransaction.SeqNum, "Sequence number should be used verbatim")
+
+	tx.Reset()
+	tx.SourceAccount.SequenceNumber = 1
+	err = tx.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.SequenceNumber(9605939170639898), tx.xdrTransaction.SeqNum, "SequenceNumber should take precedence")
+
+	retry := tx.WithSequence(9605939170639920)
+	assert.Equal(t, int64(9605939170639920), retry.SequenceNumber)
+}
+
+func TestReplaceOperation(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}, &BumpSequence{BumpTo: 10}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	err := tx.Build()
+	assert.Nil(t, err)
+	err = tx.Sign(kp0)
+	assert.Nil(t, err)
+
+	replaced, err := tx.ReplaceOperation(1, &BumpSequence{BumpTo: 20})
+	assert.Nil(t, err)
+	assert.Nil(t, replaced.xdrEnvelope, "Signatures should be cleared")
+	assert.Empty(t, replaced.xdrTransaction.Operations, "Copy should be unbuilt")
+
+	err = replaced.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.SequenceNumber(20), replaced.xdrTransaction.Operations[1].Body.MustBumpSequenceOp().BumpTo)
+
+	assert.Equal(t, int64(10), tx.Operations[1].(*BumpSequence).BumpTo, "Original should be unchanged")
+	assert.Equal(t, xdr.SequenceNumber(10), tx.xdrTransaction.Operations[1].Body.MustBumpSequenceOp().BumpTo)
+	assert.Len(t, tx.xdrEnvelope.Signatures, 1)
+
+	_, err = tx.ReplaceOperation(2, &Inflation{})
+	assert.Error(t, err)
+	_, err = tx.ReplaceOperation(-1, &Inflation{})
+	assert.Error(t, err)
+	_, err = tx.ReplaceOperation(0, nil)
+	assert.Error(t, err)
+}
+
+func TestTransactionFromXDROperationSourceAccounts(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations: []Operation{
+			&BumpSequence{BumpTo: 9605939170639999, SourceAccount: kp1.Address()},
+			&Payment{Destination: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H", Amount: "10"},
+		},
+		Network: network.TestNetworkPassphrase,
+	}
+
+	txeBase64 := buildSignEncode(tx, kp0, t)
+	decoded, err := TransactionFromXDR(txeBase64, network.TestNetworkPassphrase)
+	assert.Nil(t, err)
+	assert.Equal(t, tx.SourceAccount, decoded.SourceAccount)
+
+	if assert.Equal(t, 2, len(decoded.Operations)) {
+		bumpSequence := decoded.Operations[0].(*BumpSequence)
+		assert.Equal(t, kp1.Address(), bumpSequence.SourceAccount)
+		assert.Equal(t, int64(9605939170639999), bumpSequence.BumpTo)
+
+		payment := decoded.Operations[1].(*Payment)
+		assert.Equal(t, "", payment.SourceAccount)
+		assert.Equal(t, "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H", payment.Destination)
+		assert.Equal(t, "10.0000000", payment.Amount)
+	}
+}
+
+// verifySignaturesNaive recomputes the transaction hash for every signature, as a reference
+// for VerifySignatures.
+func verifySignaturesNaive(tx *Transaction, signers ...keypair.KP) bool {
+	for _, sig := range tx.xdrEnvelope.Signatures {
+		valid := false
+		for _, signer := range signers {
+			hash, err := tx.Hash()
+			if err != nil {
+				return false
+			}
+			if signer.Hint() == sig.Hint && signer.Verify(hash[:], sig.Signature) == nil {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return false
+		}
+	}
+	return true
+}
+
+func newMultiSignedTransaction(t testing.TB, signers []*keypair.Full) Transaction {
+	tx := Transaction{
+		SourceAccount: Account{ID: signers[0].Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	err := tx.Build()
+	assert.Nil(t, err)
+	for _, kp := range signers {
+		err = tx.Sign(kp)
+		assert.Nil(t, err)
+	}
+	return tx
+}
+
+func TestVerifySignatures(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+	tx := newMultiSignedTransaction(t, []*keypair.Full{kp0, kp1})
+
+	err := tx.VerifySignatures(kp0, kp1)
+	assert.Nil(t, err)
+	assert.True(t, verifySignaturesNaive(&tx, kp0, kp1))
+
+	err = tx.VerifySignatures(kp0)
+	assert.Error(t, err)
+	assert.False(t, verifySignaturesNaive(&tx, kp0))
+
+	tx.xdrEnvelope.Signatures[1].Signature[0] ^= 0xff
+	err = tx.VerifySignatures(kp0, kp1)
+	assert.Error(t, err)
+	assert.False(t, verifySignaturesNaive(&tx, kp0, kp1))
+}
+
+func BenchmarkVerifySignatures(b *testing.B) {
+	var signers []*keypair.Full
+	var kps []keypair.KP
+	for i := 0; i < 5; i++ {
+		kp, err := keypair.Random()
+		if err != nil {
+			b.Fatal(err)
+		}
+		signers = append(signers, kp)
+		kps = append(kps, kp)
+	}
+	tx := newMultiSignedTransaction(b, signers)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := tx.VerifySignatures(kps...)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	err := tx.Build()
+	assert.Nil(t, err)
+
+	err = tx.SignAndVerify(kp0)
+	assert.Nil(t, err)
+	assert.Len(t, tx.xdrEnvelope.Signatures, 1)
+	assert.Nil(t, tx.VerifySignatures(kp0))
+
+	tx.Network = ""
+	err = tx.SignAndVerify(kp0)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "empty network passphrase")
+	}
+	assert.Len(t, tx.xdrEnvelope.Signatures, 1)
+}
+
+func TestSignersPresent(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+	tx := newMultiSignedTransaction(t, []*keypair.Full{kp0})
+
+	present := tx.SignersPresent([]string{kp0.Address(), kp1.Address(), "GBADADDRESS"})
+	assert.Equal(t, map[string]bool{
+		kp0.Address(): true,
+		kp1.Address(): false,
+		"GBADADDRESS": false,
+	}, present)
+
+	unsigned := Transaction{}
+	assert.Equal(t, map[string]bool{kp0.Address(): false}, unsigned.SignersPresent([]string{kp0.Address()}))
+}
+
+func TestPerOperationFee(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}, &Inflation{}, &Inflation{}},
+		BaseFee:       150,
+		Network:       network.TestNetworkPassphrase,
+	}
+	assert.Equal(t, int64(0), tx.PerOperationFee(), "Unbuilt transaction has no operations")
+
+	err := tx.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.Uint32(450), tx.xdrTransaction.Fee)
+	assert.Equal(t, int64(150), tx.PerOperationFee())
+}
+
+func TestSignWithPayload(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	err := tx.Build()
+	assert.Nil(t, err)
+
+	payload := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	err = tx.SignWithPayload(kp0, payload)
+	assert.Nil(t, err)
+
+	keyHint := kp0.Hint()
+	expectedHint := xdr.SignatureHint{
+		keyHint[0] ^ 0x05,
+		keyHint[1] ^ 0x06,
+		keyHint[2] ^ 0x07,
+		keyHint[3] ^ 0x08,
+	}
+	sig := tx.xdrEnvelope.Signatures[0]
+	assert.Equal(t, expectedHint, sig.Hint)
+	assert.Nil(t, kp0.Verify(payload, sig.Signature))
+
+	err = tx.SignWithPayload(kp0, []byte{0xff})
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.SignatureHint{keyHint[0] ^ 0xff, keyHint[1], keyHint[2], keyHint[3]},
+		tx.xdrEnvelope.Signatures[1].Hint, "Short payloads should be zero padded")
+
+	err = tx.SignWithPayload(kp0, make([]byte, 65))
+	assert.Error(t, err)
+}
+
+func TestHashFromBase64(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	err := tx.Build()
+	assert.Nil(t, err)
+	err = tx.Sign(kp0)
+	assert.Nil(t, err)
+	txeBase64, err := tx.Base64()
+	assert.Nil(t, err)
+
+	expected, err := tx.Hash()
+	assert.Nil(t, err)
+	hash, err := HashFromBase64(txeBase64, network.TestNetworkPassphrase)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, hash)
+
+	_, err = HashFromBase64("AAAA", network.TestNetworkPassphrase)
+	assert.Error(t, err)
+}
+
+func TestTransactionFromXDRMalformed(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	txeBase64 := buildSignEncode(tx, kp0, t)
+
+	_, err := TransactionFromXDR("not base64!", network.TestNetworkPassphrase)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Failed to decode base 64 envelope")
+	}
+
+	txBytes, err := base64.StdEncoding.DecodeString(txeBase64)
+	assert.Nil(t, err)
+	truncated := base64.StdEncoding.EncodeToString(txBytes[:len(txBytes)-10])
+	_, err = TransactionFromXDR(truncated, network.TestNetworkPassphrase)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Failed to unmarshal XDR envelope")
+	}
+
+	decoded, err := TransactionFromXDR(txeBase64, network.TestNetworkPassphrase)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(decoded.Operations))
+}
+
+func TestAssets(t *testing.T) {
+	kp0 := newKeypair0()
+	usd := Asset{Code: "USD", Issuer: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"}
+	eur := Asset{Code: "EUR", Issuer: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"}
+
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations: []Operation{
+			&Payment{Destination: "GCCOBXW2XQNUSL467IEILE6MMCNRR66SSVL4YQADUNYYNUVREF3FIV2Z", Amount: "10", Asset: usd},
+			&ManageSellOffer{Selling: NewNativeAsset(), Buying: eur, Amount: "5", Price: "1"},
+			&ChangeTrust{Line: usd},
+			&Inflation{},
+		},
+		Network: network.TestNetworkPassphrase,
+	}
+
+	assert.Equal(t, []Asset{usd, NewNativeAsset(), eur}, tx.Assets())
+}
+
+func TestTotalNativeMoved(t *testing.T) {
+	kp0 := newKeypair0()
+	usd := Asset{Code: "USD", Issuer: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"}
+	dest := "GCCOBXW2XQNUSL467IEILE6MMCNRR66SSVL4YQADUNYYNUVREF3FIV2Z"
+
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations: []Operation{
+			&Payment{Destination: dest, Amount: "10.5", Asset: NewNativeAsset()},
+			&Payment{Destination: dest, Amount: "1000", Asset: usd},
+			&CreateAccount{Destination: dest, Amount: "2"},
+			&Inflation{},
+		},
+		Network: network.TestNetworkPassphrase,
+	}
+
+	total, err := tx.TotalNativeMoved()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(125000000), total)
+
+	tx.Operations = append(tx.Operations, &Payment{Destination: dest, Amount: "abc"})
+	_, err = tx.TotalNativeMoved()
+	assert.Error(t, err)
+}
+
+func TestCheckOperationSources(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+	unknown := "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations: []Operation{
+			&Inflation{},
+			&BumpSequence{BumpTo: 1, SourceAccount: kp1.Address()},
+		},
+		Network: network.TestNetworkPassphrase,
+	}
+
+	err := CheckOperationSources(&tx, []string{kp0.Address(), kp1.Address()})
+	assert.Nil(t, err)
+
+	tx.Operations = append(tx.Operations, &Inflation{SourceAccount: unknown})
+	err = CheckOperationSources(&tx, []string{kp0.Address(), kp1.Address()})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), unknown)
+	}
+}
+
+func TestAddDecoratedSignature(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	err := tx.Build()
+	assert.Nil(t, err)
+
+	hash, err := tx.Hash()
+	assert.Nil(t, err)
+	rawSig, err := kp0.Sign(hash[:])
+	assert.Nil(t, err)
+	sig := xdr.DecoratedSignature{Hint: xdr.SignatureHint(kp0.Hint()), Signature: xdr.Signature(rawSig)}
+
+	err = tx.AddDecoratedSignature(sig)
+	assert.Nil(t, err)
+	assert.Equal(t, []xdr.DecoratedSignature{sig}, tx.xdrEnvelope.Signatures)
+	assert.Nil(t, tx.VerifySignatures(kp0))
+
+	err = tx.AddDecoratedSignature(xdr.DecoratedSignature{Hint: sig.Hint, Signature: rawSig[:32]})
+	assert.Error(t, err)
+	assert.Equal(t, 1, len(tx.xdrEnvelope.Signatures))
+}
+
+func TestTransactionFromXDRMemoAndFee(t *testing.T) {
+	kp0 := newKeypair0()
+	dest := newKeypair1().Address()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations: []Operation{
+			&Payment{Destination: dest, Amount: "10"},
+			&Payment{Destination: dest, Amount: "20"},
+		},
+		BaseFee: 250,
+		Memo:    MemoText("deposit"),
+		Network: network.TestNetworkPassphrase,
+	}
+	txeB64 := buildSignEncode(tx, kp0, t)
+
+	decoded, err := TransactionFromXDR(txeB64, network.TestNetworkPassphrase)
+	assert.Nil(t, err)
+	assert.Equal(t, MemoText("deposit"), decoded.Memo)
+	assert.Equal(t, uint64(250), decoded.BaseFee)
+	assert.Nil(t, decoded.WarnIfMissingMemo())
+	assert.Nil(t, decoded.RequireMemoFor(map[string]bool{dest: true}))
+	assert.Contains(t, decoded.Summary(), `Memo: text "deposit"`)
+
+	decoded.Reset()
+	err = decoded.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, xdr.MemoTypeMemoText, decoded.xdrTransaction.Memo.Type)
+	assert.Equal(t, "deposit", decoded.xdrTransaction.Memo.MustText())
+	assert.Equal(t, xdr.Uint32(500), decoded.xdrTransaction.Fee)
+
+	err = decoded.Sign(kp0)
+	assert.Nil(t, err)
+	rebuilt, err := decoded.Base64()
+	assert.Nil(t, err)
+	assert.Equal(t, txeB64, rebuilt)
+}
+
+func TestLegacyEnvelopeRoundTrip(t *testing.T) {
+	// Envelopes in this XDR version use the format later named ENVELOPE_TYPE_TX_V0, whose hash
+	// is taken over the network ID, the ENVELOPE_TYPE_TX tag and the transaction.
+	kp0 := newKeypair0()
+	legacy := "AAAAAODcbeFyXKxmUWK1L6znNbKKIkPkHRJNbLktcKPqLnLFAAAAZAAiII0AAAAaAAAAAAAAAAAAAAABAAAAAAAAAAkAAAAAAAAAAeoucsUAAABAWqznvTxLfn6Q+zIloGmLDXCJQWsFPlfIf/EVFF+FfpL/gNbsvTC/U2G/ZtxMTgvqTLsBJfZAailGvPS04rfYCw=="
+
+	tx, err := TransactionFromXDR(legacy, network.TestNetworkPassphrase)
+	assert.Nil(t, err)
+
+	var payload bytes.Buffer
+	networkID := network.ID(network.TestNetworkPassphrase)
+	payload.Write(networkID[:])
+	_, err = xdr.Marshal(&payload, xdr.EnvelopeTypeEnvelopeTypeTx)
+	assert.Nil(t, err)
+	_, err = xdr.Marshal(&payload, tx.xdrEnvelope.Tx)
+	assert.Nil(t, err)
+
+	txHash, err := tx.Hash()
+	assert.Nil(t, err)
+	assert.Equal(t, hash.Hash(payload.Bytes()), txHash)
+	assert.Nil(t, tx.VerifySignatures(kp0))
+
+	reencoded, err := tx.Base64()
+	assert.Nil(t, err)
+	assert.Equal(t, legacy, reencoded)
+}
+
+func TestSummary(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations: []Operation{
+			&Payment{Destination: kp1.Address(), Amount: "10", Asset: NewNativeAsset()},
+			&ManageData{Name: "config", SourceAccount: kp1.Address()},
+			&Inflation{},
+		},
+		Memo:    MemoText("deposit"),
+		Network: network.TestNetworkPassphrase,
+	}
+
+	summary := tx.Summary()
+	assert.Contains(t, summary, "Source: "+kp0.Address())
+	assert.Contains(t, summary, "Sequence: 9605939170639898")
+	assert.Contains(t, summary, "Base fee: 100 stroops", "The default base fee should be shown")
+	assert.Contains(t, summary, `Memo: text "deposit"`)
+	assert.Contains(t, summary, "- Payment: 10 native to "+kp1.Address())
+	assert.Contains(t, summary, `- ManageData: delete "config" (source `+kp1.Address()+")")
+	assert.Contains(t, summary, "- Inflation\n")
+
+	tx.FeeSource = erroringFeeSource{}
+	assert.Contains(t, tx.Summary(), "Base fee: from fee source", "The fee source shouldn't be queried")
+	tx.BaseFee = 300
+	assert.Contains(t, tx.Summary(), "Base fee: 300 stroops")
+	tx.BaseFee = 0
+	tx.FeeSource = nil
+
+	err := tx.Build()
+	assert.Nil(t, err)
+	assert.Contains(t, tx.Summary(), "Fee: 300 stroops")
+}
+
+func TestOperationTypes(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations: []Operation{
+			&Payment{Destination: "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H", Amount: "10"},
+			&Inflation{},
+			&Payment{Destination: "GCCOBXW2XQNUSL467IEILE6MMCNRR66SSVL4YQADUNYYNUVREF3FIV2Z", Amount: "5"},
+			&ManageData{Name: "config"},
+		},
+		Network: network.TestNetworkPassphrase,
+	}
+
+	expected := []xdr.OperationType{
+		xdr.OperationTypePayment,
+		xdr.OperationTypeInflation,
+		xdr.OperationTypePayment,
+		xdr.OperationTypeManageData,
+	}
+	opTypes, err := tx.OperationTypes()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, opTypes)
+
+	counts, err := tx.OperationTypeCounts()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, counts[xdr.OperationTypePayment])
+	assert.Equal(t, 1, counts[xdr.OperationTypeInflation])
+	assert.Equal(t, 1, counts[xdr.OperationTypeManageData])
+	assert.Equal(t, 3, len(counts))
+}
+
+// customOp is an Operation implemented outside of the package's own operation types.
+type customOp struct {
+	Inflation
+	built bool
+}
+
+func (c *customOp) BuildXDR() (xdr.Operation, error) {
+	c.built = true
+	return c.Inflation.BuildXDR()
+}
+
+func TestOperationTypesUnknownOperation(t *testing.T) {
+	custom := &customOp{}
+	tx := newTestTx(&Inflation{}, custom)
+
+	_, err := tx.OperationTypes()
+	assert.EqualError(t, err, "Unknown operation *txnbuild.customOp")
+	_, err = tx.OperationTypeCounts()
+	assert.EqualError(t, err, "Unknown operation *txnbuild.customOp")
+	assert.Contains(t, tx.Summary(), "- *txnbuild.customOp\n")
+	assert.False(t, custom.built, "Operations shouldn't be built to find their type")
+}
+
+func TestSortSignatures(t *testing.T) {
+	var signers []*keypair.Full
+	for i := 0; i < 5; i++ {
+		kp, err := keypair.Random()
+		assert.Nil(t, err)
+		signers = append(signers, kp)
+	}
+	tx := newMultiSignedTransaction(t, signers)
+
+	tx.SortSignatures()
+	sorted := append([]xdr.DecoratedSignature(nil), tx.xdrEnvelope.Signatures...)
+	for i := 1; i < len(sorted); i++ {
+		assert.True(t, bytes.Compare(sorted[i-1].Hint[:], sorted[i].Hint[:]) <= 0, "Signatures should be ordered by hint")
+	}
+
+	shuffled := tx.xdrEnvelope.Signatures
+	shuffled[0], shuffled[4] = shuffled[4], shuffled[0]
+	shuffled[1], shuffled[3] = shuffled[3], shuffled[1]
+	tx.SortSignatures()
+	assert.Equal(t, sorted, tx.xdrEnvelope.Signatures)
+}
+
+func TestEnvelopeHash(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&Inflation{}},
+		Network:       network.TestNetworkPassphrase,
+	}
+	err := tx.Build()
+	assert.Nil(t, err)
+	err = tx.Sign(kp0)
+	assert.Nil(t, err)
+
+	signingHash, err := tx.Hash()
+	assert.Nil(t, err)
+	envelopeHash, err := tx.EnvelopeHash()
+	assert.Nil(t, err)
+	assert.NotEqual(t, signingHash, envelopeHash)
+
+	txBytes, err := tx.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, hash.Hash(txBytes), envelopeHash)
+
+	err = tx.Sign(kp1)
+	assert.Nil(t, err)
+	newSigningHash, err := tx.Hash()
+	assert.Nil(t, err)
+	newEnvelopeHash, err := tx.EnvelopeHash()
+	assert.Nil(t, err)
+	assert.Equal(t, signingHash, newSigningHash, "Signing hash should ignore signatures")
+	assert.NotEqual(t, envelopeHash, newEnvelopeHash, "Envelope hash should change with signatures")
+}
+
+func TestToXDR(t *testing.T) {
+	kp0 := newKeypair0()
+	tx := Transaction{
+		SourceAccount: Account{ID: kp0.Address(), SequenceNumber: 9605939170639897},
+		Operations:    []Operation{&BumpSequence{BumpTo: 9605939170639999}},
+		Network:       network.TestNetworkPassphrase,
+		Memo:          MemoText("hello"),
+	}
+
+	_, err := tx.ToXDR()
+	assert.Error(t, err, "Unbuilt transaction should be rejected")
+
+	err = tx.Build()
+	assert.Nil(t, err)
+	xdrTransaction, err := tx.ToXDR()
+	assert.Nil(t, err)
+	assert.Equal(t, tx.xdrTransaction, xdrTransaction)
+
+	xdrTransaction.SeqNum = 1
+	xdrTransaction.Operations[0].Body.BumpSequenceOp.BumpTo = 1
+	*xdrTransaction.Memo.Text = "changed"
+	assert.Equal(t, xdr.SequenceNumber(9605939170639898), tx.xdrTransaction.SeqNum)
+	assert.Equal(t, xdr.SequenceNumber(9605939170639999), tx.xdrTransaction.Operations[0].Body.MustBumpSequenceOp().BumpTo)
+	assert.Equal(t, "hello", tx.xdrTransaction.Memo.MustText())
+}
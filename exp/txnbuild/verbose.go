@@ -0,0 +1,22 @@
+package txnbuild
+
+import "fmt"
+
+// verboseErrors controls whether error messages include the offending field values.
+var verboseErrors bool
+
+// SetVerboseErrors controls whether errors returned by this package include the values of
+// the fields that caused them, such as an invalid address. Errors are concise by default.
+// It is not safe to call SetVerboseErrors concurrently with building transactions, so it
+// should be called during program initialisation.
+func SetVerboseErrors(verbose bool) {
+	verboseErrors = verbose
+}
+
+// withValue returns msg, followed by the offending value when verbose errors are enabled.
+func withValue(msg, value string) string {
+	if verboseErrors {
+		return fmt.Sprintf("%s %q", msg, value)
+	}
+	return msg
+}
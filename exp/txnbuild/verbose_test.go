@@ -0,0 +1,24 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetVerboseErrors(t *testing.T) {
+	defer SetVerboseErrors(false)
+	setOptions := SetOptions{Signer: &Signer{Address: "GBADADDRESS", Weight: 1}}
+
+	_, err := setOptions.BuildXDR()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Failed to set signer: ")
+		assert.NotContains(t, err.Error(), "GBADADDRESS")
+	}
+
+	SetVerboseErrors(true)
+	_, err = setOptions.BuildXDR()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `Failed to set signer "GBADADDRESS": `)
+	}
+}
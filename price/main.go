@@ -11,7 +11,9 @@ import (
 	"math/big"
 	"regexp"
 	"strconv"
+	"strings"
 
+	"github.com/stellar/go/amount"
 	"github.com/stellar/go/xdr"
 )
 
@@ -112,3 +114,38 @@ func floor(n *big.Rat) *big.Rat {
 func StringFromFloat64(v float64) string {
 	return strconv.FormatFloat(v, 'f', 7, 64)
 }
+
+// FromAmounts returns the price, as a decimal string, of an offer selling the
+// amount `selling` for the amount `buying`; that is, buying divided by
+// selling. The division is exact in stroops, and the result is rounded to 7
+// digits after the decimal point with trailing zeros removed. Neither amount
+// may be zero, and the rounded price must not be zero either, as a zero price
+// isn't a valid offer price.
+func FromAmounts(selling, buying string) (string, error) {
+	sellingStroops, err := amount.ParseInt64(selling)
+	if err != nil {
+		return "", fmt.Errorf("invalid selling amount %s: %v", selling, err)
+	}
+	buyingStroops, err := amount.ParseInt64(buying)
+	if err != nil {
+		return "", fmt.Errorf("invalid buying amount %s: %v", buying, err)
+	}
+
+	if sellingStroops == 0 {
+		return "", errors.New("selling amount must not be zero")
+	}
+	if buyingStroops == 0 {
+		return "", errors.New("buying amount must not be zero")
+	}
+	if sellingStroops < 0 || buyingStroops < 0 {
+		return "", errors.New("amounts must not be negative")
+	}
+
+	p := big.NewRat(buyingStroops, sellingStroops).FloatString(7)
+	if p == "0.0000000" {
+		return "", fmt.Errorf("price of %s for %s rounds to zero", buying, selling)
+	}
+	p = strings.TrimRight(p, "0")
+	p = strings.TrimSuffix(p, ".")
+	return p, nil
+}
@@ -85,3 +85,39 @@ func TestStringFromFloat64(t *testing.T) {
 		assert.Equal(t, s, price.StringFromFloat64(f))
 	}
 }
+
+func TestFromAmounts(t *testing.T) {
+	tests := []struct {
+		selling string
+		buying  string
+		price   string
+	}{
+		{"100", "50", "0.5"},
+		{"50", "100", "2"},
+		{"3", "1", "0.3333333"},
+		{"0.0000001", "922337203685.4775807", "9223372036854775807"},
+		{"20", "0.000002", "0.0000001"},
+	}
+
+	for _, tc := range tests {
+		p, err := price.FromAmounts(tc.selling, tc.buying)
+		if assert.NoError(t, err, "%s/%s", tc.buying, tc.selling) {
+			assert.Equal(t, tc.price, p)
+		}
+	}
+
+	_, err := price.FromAmounts("0", "50")
+	assert.EqualError(t, err, "selling amount must not be zero")
+
+	_, err = price.FromAmounts("10", "0")
+	assert.EqualError(t, err, "buying amount must not be zero")
+
+	_, err = price.FromAmounts("100000000", "0.000001")
+	assert.EqualError(t, err, "price of 0.000001 for 100000000 rounds to zero")
+
+	_, err = price.FromAmounts("-1", "50")
+	assert.Error(t, err)
+
+	_, err = price.FromAmounts("abc", "50")
+	assert.Error(t, err)
+}